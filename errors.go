@@ -0,0 +1,49 @@
+package api
+
+import "errors"
+
+// Sentinel errors classifying failed operations of the resource APIs.
+// Errors returned by the APIs match at most one of them with errors.Is.
+var (
+	// ErrNotFound reports that the requested resource does not exist.
+	ErrNotFound = errors.New("resource not found")
+	// ErrForbidden reports that the caller is not allowed to perform the operation.
+	ErrForbidden = errors.New("operation forbidden")
+	// ErrUnauthorized reports that the caller could not be authenticated.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrInvalidInput reports invalid arguments, rejected either locally or by the API server.
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrTimeout reports that the operation did not complete in time.
+	ErrTimeout = errors.New("operation timed out")
+	// ErrConflict reports a conflicting update or an already existing resource.
+	ErrConflict = errors.New("conflict")
+)
+
+// Error describes a failed operation on a Kubernetes resource.
+// Kind is the resource kind such as "Pod"; Namespace and Name are empty when the
+// operation is not scoped to them. Category is one of the sentinel errors of this
+// package, or nil when the failure does not fit any of them.
+//
+// Error matches its Category with errors.Is and unwraps to the underlying error, so
+// helpers such as apierrors.IsNotFound keep working on returned errors.
+type Error struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Category  error
+	Err       error
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the category and the underlying error.
+func (e *Error) Unwrap() []error {
+	if e.Category == nil {
+		return []error{e.Err}
+	}
+
+	return []error{e.Category, e.Err}
+}
@@ -6,19 +6,29 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // DeploymentAPI defines an interface for interacting with Kubernetes Deployments.
 // It provides high-level methods for retrieving and listing Deployments with input
 // validation and pagination support. Methods support retrieving individual Deployments
-// by name and listing Deployments using label or field selectors, all within the
-// context of a specific namespace.
+// by name and listing Deployments using label or field selectors, or both combined in a
+// ListQuery, all within the context of a specific namespace. Snapshot listing reads all
+// pages at a single resourceVersion and recovers from expired continue tokens, and watches
+// deliver typed change events. Metadata-only listing returns PartialObjectMetadata for inventory use.
 type DeploymentAPI interface {
 	GetDeploymentByName(ctx context.Context, namespace, name string) (*appsv1.Deployment, error)
 	ListDeploymentsByLabel(ctx context.Context, namespace string, labelSelector string,
 		timeoutSeconds time.Duration, limit int64) ([]appsv1.Deployment, error)
 	ListDeploymentsByField(ctx context.Context, namespace string, fieldSelector string,
 		timeoutSeconds time.Duration, limit int64) ([]appsv1.Deployment, error)
+	ListDeploymentsByQuery(ctx context.Context, namespace string, query ListQuery) ([]appsv1.Deployment, error)
+	ListDeploymentsSnapshot(ctx context.Context, namespace string,
+		opts SnapshotOptions) (*Snapshot[appsv1.Deployment], error)
+	WatchDeployments(ctx context.Context, namespace string,
+		opts WatchOptions) (<-chan WatchEvent[*appsv1.Deployment], error)
+	ListDeploymentsMetadata(ctx context.Context, namespace string, labelSelector string,
+		timeoutSeconds time.Duration, limit int64) ([]metav1.PartialObjectMetadata, error)
 }
 
 // NamespaceAPI defines an interface for interacting with Kubernetes Namespaces.
@@ -28,7 +38,10 @@ type DeploymentAPI interface {
 // for listing operations. Methods return namespace names as strings rather than full
 // namespace objects. The interface supports retrieving individual namespaces by name
 // and listing namespaces using various filtering options including simple listing,
-// label-based filtering, and field-based filtering.
+// label-based filtering, field-based filtering, combined queries, and consistent snapshot
+// listing.
+// Watches deliver typed change events with full namespace objects, and metadata-only
+// listing returns PartialObjectMetadata for inventory use.
 type NamespaceAPI interface {
 	GetNamespaceByName(ctx context.Context, name string) (string, error)
 	ListNamespaces(ctx context.Context, timeoutSeconds time.Duration, limit int64) ([]string, error)
@@ -36,34 +49,58 @@ type NamespaceAPI interface {
 		limit int64) ([]string, error)
 	ListNamespacesByField(ctx context.Context, fieldSelector string, timeoutSeconds time.Duration,
 		limit int64) ([]string, error)
+	ListNamespacesByQuery(ctx context.Context, query ListQuery) ([]string, error)
+	ListNamespacesSnapshot(ctx context.Context, opts SnapshotOptions) (*Snapshot[string], error)
+	WatchNamespaces(ctx context.Context, opts WatchOptions) (<-chan WatchEvent[*corev1.Namespace], error)
+	ListNamespacesMetadata(ctx context.Context, labelSelector string, timeoutSeconds time.Duration,
+		limit int64) ([]metav1.PartialObjectMetadata, error)
 }
 
 // ServiceAPI defines an interface for interacting with Kubernetes Services.
 // It provides high-level methods for retrieving and listing Services with input
 // validation and pagination support. All list operations handle fetching multiple
 // pages of results automatically. Methods support retrieving individual Services by
-// name and listing Services that match particular label or field selectors within
-// a specific namespace.
+// name and listing Services that match particular label or field selectors, or both
+// combined in a ListQuery, within a specific namespace. Snapshot listing reads all pages
+// at a single resourceVersion and recovers from expired continue tokens, and watches
+// deliver typed change events.
+// Metadata-only listing returns PartialObjectMetadata for inventory use.
 type ServiceAPI interface {
 	GetServiceByName(ctx context.Context, namespace, name string) (*corev1.Service, error)
 	ListServicesByLabel(ctx context.Context, namespace string, labelSelector string,
 		timeoutSeconds time.Duration, limit int64) ([]corev1.Service, error)
 	ListServicesByField(ctx context.Context, namespace string, fieldSelector string,
 		timeoutSeconds time.Duration, limit int64) ([]corev1.Service, error)
+	ListServicesByQuery(ctx context.Context, namespace string, query ListQuery) ([]corev1.Service, error)
+	ListServicesSnapshot(ctx context.Context, namespace string,
+		opts SnapshotOptions) (*Snapshot[corev1.Service], error)
+	WatchServices(ctx context.Context, namespace string,
+		opts WatchOptions) (<-chan WatchEvent[*corev1.Service], error)
+	ListServicesMetadata(ctx context.Context, namespace string, labelSelector string,
+		timeoutSeconds time.Duration, limit int64) ([]metav1.PartialObjectMetadata, error)
 }
 
 // PodAPI defines an interface for interacting with Kubernetes Pods.
 // It provides high-level methods for retrieving and listing Pods with input
 // validation and pagination support. All list operations handle fetching multiple
 // pages of results automatically. Methods support retrieving individual Pods by name
-// and listing Pods that match specific criteria using label or field selectors within
-// a specific namespace.
+// and listing Pods that match specific criteria using label or field selectors, or both
+// combined in a ListQuery, within a specific namespace. Snapshot listing reads all pages
+// at a single resourceVersion and recovers from expired continue tokens, and watches
+// deliver typed change events.
+// Metadata-only listing returns PartialObjectMetadata for inventory use.
 type PodAPI interface {
 	GetPodByName(ctx context.Context, namespace, name string) (*corev1.Pod, error)
 	ListPodsByLabel(ctx context.Context, namespace string, labelSelector string,
 		timeoutSeconds time.Duration, limit int64) ([]corev1.Pod, error)
 	ListPodsByField(ctx context.Context, namespace string, fieldSelector string,
 		timeoutSeconds time.Duration, limit int64) ([]corev1.Pod, error)
+	ListPodsByQuery(ctx context.Context, namespace string, query ListQuery) ([]corev1.Pod, error)
+	ListPodsSnapshot(ctx context.Context, namespace string,
+		opts SnapshotOptions) (*Snapshot[corev1.Pod], error)
+	WatchPods(ctx context.Context, namespace string, opts WatchOptions) (<-chan WatchEvent[*corev1.Pod], error)
+	ListPodsMetadata(ctx context.Context, namespace string, labelSelector string,
+		timeoutSeconds time.Duration, limit int64) ([]metav1.PartialObjectMetadata, error)
 }
 
 // K8sAuthLoader defines a mechanism for loading Kubernetes authentication configuration data.
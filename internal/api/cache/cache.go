@@ -0,0 +1,109 @@
+// Package cache provides helpers shared by the informer-backed resource APIs.
+// Objects returned by listers are owned by the informer store, so every helper
+// returns deep copies that callers are free to modify.
+package cache
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+
+	api "github.com/kaudit/k8s_client"
+	"github.com/kaudit/k8s_client/selector"
+)
+
+// Object is the constraint satisfied by pointers to typed Kubernetes objects such as *corev1.Pod.
+type Object[T any] interface {
+	*T
+	metav1.Object
+	DeepCopy() *T
+}
+
+// Items returns deep copies of the cached objects matching selector, sorted by namespace and name.
+//
+// Parameters:
+//   - objs: Objects returned by a lister.
+//   - fieldSelector: Field selector to apply; fields.Everything() keeps all objects.
+//   - fieldSet: Function exposing the selectable fields of an object.
+func Items[T any, P Object[T]](objs []P, fieldSelector fields.Selector, fieldSet func(P) fields.Set) []T {
+	result := make([]T, 0, len(objs))
+
+	for _, obj := range objs {
+		if !fieldSelector.Empty() && !fieldSelector.Matches(fieldSet(obj)) {
+			continue
+		}
+
+		result = append(result, *obj.DeepCopy())
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		a, b := P(&result[i]), P(&result[j])
+		if a.GetNamespace() != b.GetNamespace() {
+			return a.GetNamespace() < b.GetNamespace()
+		}
+		return a.GetName() < b.GetName()
+	})
+
+	return result
+}
+
+// ObjectMetaFields returns the metadata fields selectable on every resource.
+func ObjectMetaFields(obj metav1.Object) fields.Set {
+	return fields.Set{
+		"metadata.name":      obj.GetName(),
+		"metadata.namespace": obj.GetNamespace(),
+	}
+}
+
+// Servable reports whether query can be answered from the cache.
+// Queries pinned to a resourceVersion need the API server to honor their consistency.
+func Servable(query api.ListQuery) bool {
+	return query.ResourceVersion == "" && query.ResourceVersionMatch == ""
+}
+
+// Covers reports whether namespace is held by a cache restricted to scope.
+// An empty scope covers all namespaces.
+func Covers(scope, namespace string) bool {
+	return scope == "" || scope == namespace
+}
+
+// Selectors parses the label and field selectors of a validated query on resource.
+// Empty selectors match everything.
+func Selectors(resource selector.Resource, query api.ListQuery) (labels.Selector, fields.Selector, error) {
+	labelSelector, err := labels.Parse(query.LabelSelector)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid label selector: %w", err)
+	}
+
+	fieldSelector, err := Fields(resource, query.FieldSelector)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid field selector: %w", err)
+	}
+
+	return labelSelector, fieldSelector, nil
+}
+
+// Fields parses a field selector on resource.
+// Fields the API server cannot select the resource by are rejected, as the cache would
+// otherwise match them against an empty value instead of failing like the API server.
+//
+// Returns an error wrapping selector.ErrUnsupportedField for such fields.
+func Fields(resource selector.Resource, fieldSelector string) (fields.Selector, error) {
+	parsed, err := fields.ParseSelector(fieldSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	supported := selector.SupportedFields(resource)
+	for _, requirement := range parsed.Requirements() {
+		if !slices.Contains(supported, requirement.Field) {
+			return nil, fmt.Errorf("%w: %q on %s", selector.ErrUnsupportedField, requirement.Field, resource)
+		}
+	}
+
+	return parsed, nil
+}
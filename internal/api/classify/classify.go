@@ -0,0 +1,74 @@
+// Package classify maps failures of the resource APIs to the error taxonomy of the api package.
+// Resource packages wrap every returned error with Invalid or Wrap so that callers can
+// match api.ErrNotFound and friends without inspecting apierrors themselves.
+package classify
+
+import (
+	"context"
+	"errors"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	api "github.com/kaudit/k8s_client"
+)
+
+// Invalid wraps a local validation failure as an *api.Error with category api.ErrInvalidInput.
+func Invalid(kind, namespace, name string, err error) error {
+	return &api.Error{
+		Kind:      kind,
+		Namespace: namespace,
+		Name:      name,
+		Category:  api.ErrInvalidInput,
+		Err:       err,
+	}
+}
+
+// Wrap wraps an API failure as an *api.Error with the category derived from err.
+// Errors that already are an *api.Error are returned unchanged.
+func Wrap(kind, namespace, name string, err error) error {
+	var apiErr *api.Error
+	if errors.As(err, &apiErr) {
+		return err
+	}
+
+	return &api.Error{
+		Kind:      kind,
+		Namespace: namespace,
+		Name:      name,
+		Category:  Category(err),
+		Err:       err,
+	}
+}
+
+// Category returns the sentinel error of the api package describing err, or nil if
+// err does not fit any of them.
+func Category(err error) error {
+	categories := []struct {
+		match    func(error) bool
+		category error
+	}{
+		{apierrors.IsNotFound, api.ErrNotFound},
+		{apierrors.IsUnauthorized, api.ErrUnauthorized},
+		{apierrors.IsForbidden, api.ErrForbidden},
+		{apierrors.IsConflict, api.ErrConflict},
+		{apierrors.IsAlreadyExists, api.ErrConflict},
+		{apierrors.IsInvalid, api.ErrInvalidInput},
+		{apierrors.IsBadRequest, api.ErrInvalidInput},
+		{apierrors.IsTimeout, api.ErrTimeout},
+		{apierrors.IsServerTimeout, api.ErrTimeout},
+		{isDeadlineExceeded, api.ErrTimeout},
+	}
+
+	for _, c := range categories {
+		if c.match(err) {
+			return c.category
+		}
+	}
+
+	return nil
+}
+
+// isDeadlineExceeded reports whether err was caused by an expired context deadline.
+func isDeadlineExceeded(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded)
+}
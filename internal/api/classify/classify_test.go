@@ -0,0 +1,82 @@
+package classify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	api "github.com/kaudit/k8s_client"
+)
+
+func TestWrap(t *testing.T) {
+	resource := schema.GroupResource{Resource: "pods"}
+	kind := schema.GroupKind{Kind: "Pod"}
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{name: "Not found", err: apierrors.NewNotFound(resource, "web"), want: api.ErrNotFound},
+		{name: "Forbidden", err: apierrors.NewForbidden(resource, "web", errors.New("denied")), want: api.ErrForbidden},
+		{name: "Unauthorized", err: apierrors.NewUnauthorized("expired token"), want: api.ErrUnauthorized},
+		{name: "Conflict", err: apierrors.NewConflict(resource, "web", errors.New("modified")), want: api.ErrConflict},
+		{name: "Already exists", err: apierrors.NewAlreadyExists(resource, "web"), want: api.ErrConflict},
+		{name: "Invalid", err: apierrors.NewInvalid(kind, "web", field.ErrorList{}), want: api.ErrInvalidInput},
+		{name: "Bad request", err: apierrors.NewBadRequest("bad selector"), want: api.ErrInvalidInput},
+		{name: "Timeout", err: apierrors.NewTimeoutError("slow", 1), want: api.ErrTimeout},
+		{name: "Server timeout", err: apierrors.NewServerTimeout(resource, "list", 1), want: api.ErrTimeout},
+		{name: "Context deadline", err: fmt.Errorf("list: %w", context.DeadlineExceeded), want: api.ErrTimeout},
+		{name: "Unclassified", err: errors.New("connection refused"), want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wrapped := fmt.Errorf("failed to get pod %q: %w", "web", tt.err)
+			err := Wrap("Pod", "default", "web", wrapped)
+
+			var apiErr *api.Error
+			require.ErrorAs(t, err, &apiErr)
+			assert.Equal(t, "Pod", apiErr.Kind)
+			assert.Equal(t, "default", apiErr.Namespace)
+			assert.Equal(t, "web", apiErr.Name)
+			assert.Equal(t, tt.want, apiErr.Category)
+			assert.Equal(t, wrapped.Error(), err.Error())
+			assert.ErrorIs(t, err, tt.err)
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+
+	t.Run("Keeps apierrors helpers working", func(t *testing.T) {
+		err := Wrap("Pod", "default", "web", apierrors.NewNotFound(resource, "web"))
+
+		assert.True(t, apierrors.IsNotFound(err))
+		assert.NotErrorIs(t, err, api.ErrForbidden)
+	})
+
+	t.Run("Does not wrap twice", func(t *testing.T) {
+		inner := Invalid("Pod", "default", "", errors.New("invalid limit"))
+		err := Wrap("Pod", "other", "", inner)
+
+		assert.Same(t, inner, err)
+	})
+}
+
+func TestInvalid(t *testing.T) {
+	err := Invalid("Service", "default", "web", errors.New("invalid service name"))
+
+	require.ErrorIs(t, err, api.ErrInvalidInput)
+	var apiErr *api.Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "Service", apiErr.Kind)
+	assert.Equal(t, "invalid service name", err.Error())
+}
@@ -0,0 +1,170 @@
+package deployment
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kaudit/val"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+
+	api "github.com/kaudit/k8s_client"
+	"github.com/kaudit/k8s_client/internal/api/cache"
+	"github.com/kaudit/k8s_client/internal/api/classify"
+	"github.com/kaudit/k8s_client/internal/api/options"
+	"github.com/kaudit/k8s_client/selector"
+)
+
+// CachedDeploymentAPI serves deployment reads from a shared informer cache.
+// Operations that need the API server, such as snapshots and watches, are delegated
+// to the embedded DeploymentAPI.
+type CachedDeploymentAPI struct {
+	*DeploymentAPI
+	lister appslisters.DeploymentLister
+	scope  string
+}
+
+// NewCachedDeploymentAPI creates a new CachedDeploymentAPI instance backed by the deployment
+// informer of the given factory.
+// The informer is registered with the factory; the caller is responsible for starting it.
+// A factory restricted to one namespace must be declared with options.WithCacheNamespace.
+// It returns an implementation of the api.DeploymentAPI interface.
+func NewCachedDeploymentAPI(client kubernetes.Interface, factory informers.SharedInformerFactory,
+	opts ...options.Option) api.DeploymentAPI {
+
+	return &CachedDeploymentAPI{
+		DeploymentAPI: newDeploymentAPI(client, opts...),
+		lister:        factory.Apps().V1().Deployments().Lister(),
+		scope:         options.Apply(opts...).CacheNamespace,
+	}
+}
+
+// GetDeploymentByName retrieves a specific Deployment by namespace and name from the informer cache.
+//
+// Parameters:
+//   - ctx: Context of the API server request for namespaces outside the cache.
+//   - namespace: Namespace of the deployment (must be non-empty).
+//   - name: Name of the deployment (must be non-empty).
+//
+// Returns a copy of the cached *appsv1.Deployment or an error if not found or invalid.
+func (c *CachedDeploymentAPI) GetDeploymentByName(ctx context.Context, namespace, name string) (*appsv1.Deployment, error) {
+	if !cache.Covers(c.scope, namespace) {
+		return c.DeploymentAPI.GetDeploymentByName(ctx, namespace, name)
+	}
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, classify.Invalid(kind, namespace, name, fmt.Errorf("invalid namespace: %w", err))
+	}
+	if err := val.ValidateWithTag(name, "required"); err != nil {
+		return nil, classify.Invalid(kind, namespace, name, fmt.Errorf("invalid deployment name: %w", err))
+	}
+
+	deploy, err := c.lister.Deployments(namespace).Get(name)
+	if err != nil {
+		return nil, classify.Wrap(kind, namespace, name,
+			fmt.Errorf("failed to get deployment %q in namespace %q: %w", name, namespace, err))
+	}
+
+	return deploy.DeepCopy(), nil
+}
+
+// ListDeploymentsByLabel lists cached deployments by namespace and label selector.
+// Namespaces outside the cache are listed from the API server; otherwise the timeout and
+// limit are validated for interface compatibility but not used.
+//
+// Returns all matching deployments sorted by name or an error if validation fails.
+func (c *CachedDeploymentAPI) ListDeploymentsByLabel(ctx context.Context, namespace string, labelSelector string,
+	timeoutSeconds time.Duration, limit int64) ([]appsv1.Deployment, error) {
+
+	if !cache.Covers(c.scope, namespace) {
+		return c.DeploymentAPI.ListDeploymentsByLabel(ctx, namespace, labelSelector, timeoutSeconds, limit)
+	}
+	if err := validateInput(namespace, timeoutSeconds, limit); err != nil {
+		return nil, err
+	}
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, classify.Invalid(kind, namespace, "", fmt.Errorf("invalid label selector: %w", err))
+	}
+
+	parsed, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, classify.Invalid(kind, namespace, "", fmt.Errorf("invalid label selector: %w", err))
+	}
+
+	return c.list(namespace, parsed, fields.Everything())
+}
+
+// ListDeploymentsByField lists cached deployments by namespace and field selector.
+// Namespaces outside the cache are listed from the API server; otherwise the timeout and
+// limit are validated for interface compatibility but not used.
+//
+// Returns all matching deployments sorted by name or an error if validation fails.
+func (c *CachedDeploymentAPI) ListDeploymentsByField(ctx context.Context, namespace string, fieldSelector string,
+	timeoutSeconds time.Duration, limit int64) ([]appsv1.Deployment, error) {
+
+	if !cache.Covers(c.scope, namespace) {
+		return c.DeploymentAPI.ListDeploymentsByField(ctx, namespace, fieldSelector, timeoutSeconds, limit)
+	}
+	if err := validateInput(namespace, timeoutSeconds, limit); err != nil {
+		return nil, err
+	}
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, classify.Invalid(kind, namespace, "", fmt.Errorf("invalid field selector: %w", err))
+	}
+
+	parsed, err := cache.Fields(selector.Deployments, fieldSelector)
+	if err != nil {
+		return nil, classify.Invalid(kind, namespace, "", fmt.Errorf("invalid field selector: %w", err))
+	}
+
+	return c.list(namespace, labels.Everything(), parsed)
+}
+
+// ListDeploymentsByQuery lists cached deployments by namespace and combined query.
+// Queries pinned to a resourceVersion or outside the namespace of the cache are delegated
+// to the API server; otherwise the timeout and limit are validated for interface
+// compatibility but not used.
+//
+// Returns all matching deployments sorted by name or an error if validation fails.
+func (c *CachedDeploymentAPI) ListDeploymentsByQuery(ctx context.Context, namespace string,
+	query api.ListQuery) ([]appsv1.Deployment, error) {
+
+	if !cache.Servable(query) || !cache.Covers(c.scope, namespace) {
+		return c.DeploymentAPI.ListDeploymentsByQuery(ctx, namespace, query)
+	}
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, classify.Invalid(kind, namespace, "", fmt.Errorf("invalid namespace: %w", err))
+	}
+	if err := val.ValidateStruct(query); err != nil {
+		return nil, classify.Invalid(kind, namespace, "", fmt.Errorf("invalid list query: %w", err))
+	}
+
+	labelSelector, fieldSelector, err := cache.Selectors(selector.Deployments, query)
+	if err != nil {
+		return nil, classify.Invalid(kind, namespace, "", err)
+	}
+
+	return c.list(namespace, labelSelector, fieldSelector)
+}
+
+// list returns copies of the cached deployments in namespace matching both selectors.
+func (c *CachedDeploymentAPI) list(namespace string, labelSelector labels.Selector,
+	fieldSelector fields.Selector) ([]appsv1.Deployment, error) {
+
+	deployments, err := c.lister.Deployments(namespace).List(labelSelector)
+	if err != nil {
+		return nil, classify.Wrap(kind, namespace, "",
+			fmt.Errorf("failed to list cached deployments in namespace %q: %w", namespace, err))
+	}
+
+	return cache.Items(deployments, fieldSelector, deploymentFields), nil
+}
+
+// deploymentFields returns the selectable fields of a deployment as evaluated by the API server.
+func deploymentFields(deploy *appsv1.Deployment) fields.Set {
+	return cache.ObjectMetaFields(deploy)
+}
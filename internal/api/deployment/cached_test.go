@@ -0,0 +1,137 @@
+package deployment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+
+	api "github.com/kaudit/k8s_client"
+	"github.com/kaudit/k8s_client/selector"
+)
+
+func newSyncedCachedDeploymentAPI(t *testing.T, objects ...*appsv1.Deployment) *CachedDeploymentAPI {
+	t.Helper()
+
+	fakeClient := fake.NewClientset()
+	for _, obj := range objects {
+		_, err := fakeClient.AppsV1().Deployments(obj.Namespace).Create(context.Background(), obj, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	factory := informers.NewSharedInformerFactory(fakeClient, 0)
+	deploymentAPI := NewCachedDeploymentAPI(fakeClient, factory)
+
+	stopCh := make(chan struct{})
+	t.Cleanup(func() {
+		close(stopCh)
+		factory.Shutdown()
+	})
+	factory.Start(stopCh)
+	for _, synced := range factory.WaitForCacheSync(stopCh) {
+		require.True(t, synced)
+	}
+
+	impl, ok := deploymentAPI.(*CachedDeploymentAPI)
+	require.True(t, ok)
+
+	return impl
+}
+
+func TestCachedDeploymentAPI(t *testing.T) {
+	deploymentAPI := newSyncedCachedDeploymentAPI(t,
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "test-namespace", Labels: map[string]string{"app": "web"}},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "test-namespace", Labels: map[string]string{"app": "web"}},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "db-1", Namespace: "other-namespace", Labels: map[string]string{"app": "db"}},
+		},
+	)
+	ctx := context.Background()
+
+	t.Run("Get cached deployment", func(t *testing.T) {
+		deploy, err := deploymentAPI.GetDeploymentByName(ctx, "test-namespace", "web-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "web-1", deploy.Name)
+
+		deploy.Labels["app"] = "mutated"
+		again, err := deploymentAPI.GetDeploymentByName(ctx, "test-namespace", "web-1")
+		require.NoError(t, err)
+		assert.Equal(t, "web", again.Labels["app"])
+	})
+
+	t.Run("Get missing deployment", func(t *testing.T) {
+		deploy, err := deploymentAPI.GetDeploymentByName(ctx, "test-namespace", "missing")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get deployment")
+		assert.ErrorIs(t, err, api.ErrNotFound)
+		assert.Nil(t, deploy)
+	})
+
+	t.Run("List by label sorted by name", func(t *testing.T) {
+		deployments, err := deploymentAPI.ListDeploymentsByLabel(ctx, "test-namespace", "app=web", 2*time.Second, 1)
+
+		require.NoError(t, err)
+		require.Len(t, deployments, 2)
+		assert.Equal(t, "web-1", deployments[0].Name)
+		assert.Equal(t, "web-2", deployments[1].Name)
+	})
+
+	t.Run("List by field", func(t *testing.T) {
+		deployments, err := deploymentAPI.ListDeploymentsByField(ctx, "test-namespace", "metadata.name=web-1", 2*time.Second, 1)
+
+		require.NoError(t, err)
+		require.Len(t, deployments, 1)
+		assert.Equal(t, "web-1", deployments[0].Name)
+	})
+
+	t.Run("List by query combining selectors", func(t *testing.T) {
+		items, err := deploymentAPI.ListDeploymentsByQuery(ctx, "test-namespace", api.ListQuery{
+			LabelSelector:  "app=web",
+			FieldSelector:  "metadata.name=web-2",
+			TimeoutSeconds: 2 * time.Second,
+			Limit:          1,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, "web-2", items[0].Name)
+	})
+
+	t.Run("Invalid input", func(t *testing.T) {
+		_, err := deploymentAPI.ListDeploymentsByLabel(ctx, "", "app=web", 2*time.Second, 1)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid namespace")
+
+		_, err = deploymentAPI.ListDeploymentsByField(ctx, "test-namespace", "spec.unknown=x", 2*time.Second, 1)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid field selector")
+	})
+
+	t.Run("Unsupported field", func(t *testing.T) {
+		for _, fieldSelector := range []string{"status.phase=x", "status.phase!=x"} {
+			_, err := deploymentAPI.ListDeploymentsByField(ctx, "test-namespace", fieldSelector, 2*time.Second, 1)
+			require.ErrorIs(t, err, api.ErrInvalidInput)
+			require.ErrorIs(t, err, selector.ErrUnsupportedField)
+
+			_, err = deploymentAPI.ListDeploymentsByQuery(ctx, "test-namespace", api.ListQuery{
+				FieldSelector:  fieldSelector,
+				TimeoutSeconds: 2 * time.Second,
+				Limit:          1,
+			})
+			require.ErrorIs(t, err, api.ErrInvalidInput)
+			require.ErrorIs(t, err, selector.ErrUnsupportedField)
+		}
+	})
+}
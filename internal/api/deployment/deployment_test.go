@@ -2,14 +2,23 @@ package deployment
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes/fake"
+	metadatafake "k8s.io/client-go/metadata/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	api "github.com/kaudit/k8s_client"
+	"github.com/kaudit/k8s_client/internal/api/options"
 )
 
 func TestDeploymentAPI_New(t *testing.T) {
@@ -124,6 +133,7 @@ func TestDeploymentAPI_GetDeploymentByName(t *testing.T) {
 		deploymentName string
 		wantErr        bool
 		errorContains  string
+		wantErrIs      error
 	}{
 		{
 			name:           "Successfully get deployment",
@@ -137,6 +147,7 @@ func TestDeploymentAPI_GetDeploymentByName(t *testing.T) {
 			deploymentName: "test-deployment",
 			wantErr:        true,
 			errorContains:  "invalid namespace",
+			wantErrIs:      api.ErrInvalidInput,
 		},
 		{
 			name:           "Empty deployment name",
@@ -144,6 +155,7 @@ func TestDeploymentAPI_GetDeploymentByName(t *testing.T) {
 			deploymentName: "",
 			wantErr:        true,
 			errorContains:  "invalid deployment name",
+			wantErrIs:      api.ErrInvalidInput,
 		},
 		{
 			name:           "Deployment not found",
@@ -151,6 +163,7 @@ func TestDeploymentAPI_GetDeploymentByName(t *testing.T) {
 			deploymentName: "nonexistent-deployment",
 			wantErr:        true,
 			errorContains:  "failed to get deployment",
+			wantErrIs:      api.ErrNotFound,
 		},
 	}
 
@@ -164,6 +177,10 @@ func TestDeploymentAPI_GetDeploymentByName(t *testing.T) {
 				if tt.errorContains != "" {
 					assert.Contains(t, err.Error(), tt.errorContains)
 				}
+				require.ErrorIs(t, err, tt.wantErrIs)
+				var apiErr *api.Error
+				require.ErrorAs(t, err, &apiErr)
+				assert.Equal(t, "Deployment", apiErr.Kind)
 				assert.Nil(t, deployment)
 			} else {
 				require.NoError(t, err)
@@ -465,3 +482,409 @@ func TestDeploymentAPI_ListDeploymentsByField(t *testing.T) {
 		})
 	}
 }
+
+func TestDeploymentAPI_ListDeploymentsByQuery(t *testing.T) {
+	// Serve two pages and record the options of every request
+	fakeClient := fake.NewClientset()
+	var requests []metav1.ListOptions
+	fakeClient.PrependReactor("list", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		opts := action.(k8stesting.ListActionImpl).GetListOptions()
+		requests = append(requests, opts)
+		if opts.Continue == "" {
+			return true, &appsv1.DeploymentList{
+				ListMeta: metav1.ListMeta{ResourceVersion: "10", Continue: "token"},
+				Items:    []appsv1.Deployment{{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "test-namespace", Labels: map[string]string{"app": "web"}}}},
+			}, nil
+		}
+		return true, &appsv1.DeploymentList{
+			ListMeta: metav1.ListMeta{ResourceVersion: "10"},
+			Items:    []appsv1.Deployment{{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "test-namespace", Labels: map[string]string{"app": "web"}}}},
+		}, nil
+	})
+
+	deploymentAPI := NewDeploymentAPI(fakeClient)
+
+	tests := []struct {
+		name          string
+		namespace     string
+		query         api.ListQuery
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:      "Combined selectors with resourceVersion",
+			namespace: "test-namespace",
+			query: api.ListQuery{
+				LabelSelector:        "app=web",
+				FieldSelector:        "metadata.name=web-2",
+				ResourceVersion:      "5",
+				ResourceVersionMatch: metav1.ResourceVersionMatchNotOlderThan,
+				TimeoutSeconds:       2 * time.Second,
+				Limit:                1,
+			},
+			wantErr: false,
+		},
+		{
+			name:          "Empty namespace",
+			namespace:     "",
+			query:         api.ListQuery{TimeoutSeconds: 2 * time.Second, Limit: 1},
+			wantErr:       true,
+			errorContains: "invalid namespace",
+		},
+		{
+			name:          "Invalid label selector",
+			namespace:     "test-namespace",
+			query:         api.ListQuery{LabelSelector: "app in (web", TimeoutSeconds: 2 * time.Second, Limit: 1},
+			wantErr:       true,
+			errorContains: "invalid list query",
+		},
+		{
+			name:          "Invalid field selector",
+			namespace:     "test-namespace",
+			query:         api.ListQuery{FieldSelector: "spec.unknown=x", TimeoutSeconds: 2 * time.Second, Limit: 1},
+			wantErr:       true,
+			errorContains: "invalid list query",
+		},
+		{
+			name:      "Match without resourceVersion",
+			namespace: "test-namespace",
+			query: api.ListQuery{
+				ResourceVersionMatch: metav1.ResourceVersionMatchExact,
+				TimeoutSeconds:       2 * time.Second,
+				Limit:                1,
+			},
+			wantErr:       true,
+			errorContains: "invalid list query",
+		},
+		{
+			name:          "Invalid limit",
+			namespace:     "test-namespace",
+			query:         api.ListQuery{TimeoutSeconds: 2 * time.Second},
+			wantErr:       true,
+			errorContains: "invalid list query",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests = nil
+			ctx := context.Background()
+			items, err := deploymentAPI.ListDeploymentsByQuery(ctx, tt.namespace, tt.query)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, items)
+				assert.Empty(t, requests)
+				return
+			}
+
+			require.NoError(t, err)
+			names := make([]string, 0, len(items))
+			for _, item := range items {
+				names = append(names, item.Name)
+			}
+			assert.Equal(t, []string{"a", "b"}, names)
+			require.Len(t, requests, 2)
+			assert.Equal(t, tt.query.LabelSelector, requests[0].LabelSelector)
+			assert.Equal(t, tt.query.FieldSelector, requests[0].FieldSelector)
+			assert.Equal(t, "5", requests[0].ResourceVersion)
+			assert.Equal(t, metav1.ResourceVersionMatchNotOlderThan, requests[0].ResourceVersionMatch)
+			assert.Equal(t, "token", requests[1].Continue)
+			assert.Empty(t, requests[1].ResourceVersion)
+			assert.Empty(t, requests[1].ResourceVersionMatch)
+		})
+	}
+}
+
+func TestDeploymentAPI_ListDeploymentsSnapshot(t *testing.T) {
+	// Serve a first page, expire its continue token once and then serve the full list
+	fakeClient := fake.NewClientset()
+	calls := 0
+	fakeClient.PrependReactor("list", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		calls++
+		opts := action.(k8stesting.ListActionImpl).GetListOptions()
+		switch {
+		case calls == 1:
+			return true, &appsv1.DeploymentList{
+				ListMeta: metav1.ListMeta{ResourceVersion: "10", Continue: "token"},
+				Items:    []appsv1.Deployment{{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "test-namespace"}}},
+			}, nil
+		case opts.Continue != "":
+			return true, nil, apierrors.NewResourceExpired("continue token too old")
+		default:
+			return true, &appsv1.DeploymentList{
+				ListMeta: metav1.ListMeta{ResourceVersion: "20"},
+				Items: []appsv1.Deployment{
+					{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "test-namespace"}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "test-namespace"}},
+				},
+			}, nil
+		}
+	})
+
+	deploymentAPI := NewDeploymentAPI(fakeClient)
+
+	tests := []struct {
+		name          string
+		namespace     string
+		opts          api.SnapshotOptions
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:      "Restarts after expired continue token",
+			namespace: "test-namespace",
+			opts:      api.SnapshotOptions{TimeoutSeconds: 2 * time.Second, Limit: 1},
+			wantErr:   false,
+		},
+		{
+			name:          "Empty namespace",
+			namespace:     "",
+			opts:          api.SnapshotOptions{TimeoutSeconds: 2 * time.Second, Limit: 1},
+			wantErr:       true,
+			errorContains: "invalid namespace",
+		},
+		{
+			name:          "Invalid label selector",
+			namespace:     "test-namespace",
+			opts:          api.SnapshotOptions{LabelSelector: "app in (web", TimeoutSeconds: 2 * time.Second, Limit: 1},
+			wantErr:       true,
+			errorContains: "invalid snapshot options",
+		},
+		{
+			name:          "Invalid limit",
+			namespace:     "test-namespace",
+			opts:          api.SnapshotOptions{TimeoutSeconds: 2 * time.Second},
+			wantErr:       true,
+			errorContains: "invalid snapshot options",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			snapshot, err := deploymentAPI.ListDeploymentsSnapshot(ctx, tt.namespace, tt.opts)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, snapshot)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "20", snapshot.ResourceVersion)
+			assert.Equal(t, 1, snapshot.Restarts)
+			assert.True(t, snapshot.Complete)
+			names := make([]string, 0, len(snapshot.Items))
+			for _, item := range snapshot.Items {
+				names = append(names, item.Name)
+			}
+			assert.Equal(t, []string{"a", "b"}, names)
+		})
+	}
+}
+
+func TestDeploymentAPI_WatchDeployments(t *testing.T) {
+	fakeClient := fake.NewClientset()
+	deploymentAPI := NewDeploymentAPI(fakeClient)
+
+	t.Run("Delivers added event", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events, err := deploymentAPI.WatchDeployments(ctx, "test-namespace", api.WatchOptions{})
+		require.NoError(t, err)
+
+		_, err = fakeClient.AppsV1().Deployments("test-namespace").Create(ctx,
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "watched", Namespace: "test-namespace"}}, metav1.CreateOptions{})
+		require.NoError(t, err)
+
+		select {
+		case event := <-events:
+			assert.Equal(t, api.EventAdded, event.Type)
+			assert.Equal(t, "watched", event.Object.Name)
+		case <-time.After(5 * time.Second):
+			t.Fatal("no event received")
+		}
+	})
+
+	t.Run("Empty namespace", func(t *testing.T) {
+		events, err := deploymentAPI.WatchDeployments(context.Background(), "", api.WatchOptions{})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid namespace")
+		assert.Nil(t, events)
+	})
+
+	t.Run("Invalid label selector", func(t *testing.T) {
+		events, err := deploymentAPI.WatchDeployments(context.Background(), "test-namespace", api.WatchOptions{LabelSelector: "app in (web"})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid watch options")
+		assert.Nil(t, events)
+	})
+}
+
+func TestDeploymentAPI_ListDeploymentsMetadata(t *testing.T) {
+	scheme := metadatafake.NewTestScheme()
+	require.NoError(t, metav1.AddMetaToScheme(scheme))
+
+	newMetadata := func(name string, labels map[string]string) *metav1.PartialObjectMetadata {
+		return &metav1.PartialObjectMetadata{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: name, Labels: labels},
+		}
+	}
+	metadataClient := metadatafake.NewSimpleMetadataClient(scheme,
+		newMetadata("web", map[string]string{"app": "web"}),
+		newMetadata("db", map[string]string{"app": "db"}),
+	)
+
+	tests := []struct {
+		name           string
+		api            *DeploymentAPI
+		namespace      string
+		labelSelector  string
+		timeoutSeconds time.Duration
+		limit          int64
+		expectedNames  []string
+		wantErr        bool
+		errorContains  string
+	}{
+		{
+			name:           "List all metadata",
+			api:            newDeploymentAPI(fake.NewClientset(), options.WithMetadataClient(metadataClient)),
+			namespace:      "test-namespace",
+			timeoutSeconds: 2 * time.Second,
+			limit:          1,
+			expectedNames:  []string{"db", "web"},
+		},
+		{
+			name:           "List metadata by label",
+			api:            newDeploymentAPI(fake.NewClientset(), options.WithMetadataClient(metadataClient)),
+			namespace:      "test-namespace",
+			labelSelector:  "app=web",
+			timeoutSeconds: 2 * time.Second,
+			limit:          1,
+			expectedNames:  []string{"web"},
+		},
+		{
+			name:           "Empty namespace",
+			api:            newDeploymentAPI(fake.NewClientset(), options.WithMetadataClient(metadataClient)),
+			namespace:      "",
+			timeoutSeconds: 2 * time.Second,
+			limit:          1,
+			wantErr:        true,
+			errorContains:  "invalid namespace",
+		},
+		{
+			name:           "Invalid label selector",
+			api:            newDeploymentAPI(fake.NewClientset(), options.WithMetadataClient(metadataClient)),
+			namespace:      "test-namespace",
+			labelSelector:  "app in (web",
+			timeoutSeconds: 2 * time.Second,
+			limit:          1,
+			wantErr:        true,
+			errorContains:  "invalid label selector",
+		},
+		{
+			name:           "Missing metadata client",
+			api:            newDeploymentAPI(fake.NewClientset()),
+			namespace:      "test-namespace",
+			timeoutSeconds: 2 * time.Second,
+			limit:          1,
+			wantErr:        true,
+			errorContains:  options.ErrNoMetadataClient.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := tt.api.ListDeploymentsMetadata(context.Background(), tt.namespace, tt.labelSelector,
+				tt.timeoutSeconds, tt.limit)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, items)
+				return
+			}
+
+			require.NoError(t, err)
+			names := make([]string, 0, len(items))
+			for _, item := range items {
+				names = append(names, item.Name)
+			}
+			assert.ElementsMatch(t, tt.expectedNames, names)
+		})
+	}
+}
+
+func TestDeploymentAPI_RetryPolicy(t *testing.T) {
+	// Fail the first calls of every verb and count all attempts
+	fakeClient := fake.NewClientset(&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "test-namespace", Labels: map[string]string{"app": "web"}}})
+	var getFailures, listFailures, getCalls int
+	var getErr error
+	fakeClient.PrependReactor("get", "deployments", func(k8stesting.Action) (bool, runtime.Object, error) {
+		getCalls++
+		if getFailures > 0 {
+			getFailures--
+			return true, nil, getErr
+		}
+		return false, nil, nil
+	})
+	fakeClient.PrependReactor("list", "deployments", func(k8stesting.Action) (bool, runtime.Object, error) {
+		if listFailures > 0 {
+			listFailures--
+			return true, nil, apierrors.NewServiceUnavailable("restarting")
+		}
+		return false, nil, nil
+	})
+
+	policy := api.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	deploymentAPI := NewDeploymentAPI(fakeClient, options.WithRetryPolicy(policy))
+	ctx := context.Background()
+
+	t.Run("Retries transient failures", func(t *testing.T) {
+		getFailures, listFailures, getCalls = 2, 2, 0
+		getErr = apierrors.NewTooManyRequests("slow down", 0)
+
+		got, err := deploymentAPI.GetDeploymentByName(ctx, "test-namespace", "web")
+		require.NoError(t, err)
+		assert.Equal(t, "web", got.Name)
+		assert.Equal(t, 3, getCalls)
+
+		items, err := deploymentAPI.ListDeploymentsByLabel(ctx, "test-namespace", "app=web", 2*time.Second, 1)
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+	})
+
+	t.Run("Gives up after max attempts", func(t *testing.T) {
+		listFailures = 3
+
+		_, err := deploymentAPI.ListDeploymentsByLabel(ctx, "test-namespace", "app=web", 2*time.Second, 1)
+		require.Error(t, err)
+		assert.True(t, apierrors.IsServiceUnavailable(err))
+	})
+
+	t.Run("Does not retry permanent errors", func(t *testing.T) {
+		getFailures, getCalls = 3, 0
+		getErr = apierrors.NewForbidden(schema.GroupResource{Resource: "deployments"}, "web", errors.New("denied"))
+
+		_, err := deploymentAPI.GetDeploymentByName(ctx, "test-namespace", "web")
+		require.ErrorIs(t, err, api.ErrForbidden)
+		assert.Equal(t, 1, getCalls)
+	})
+
+	t.Run("Attempts once without policy", func(t *testing.T) {
+		getFailures, getCalls = 1, 0
+		getErr = apierrors.NewTooManyRequests("slow down", 0)
+
+		_, err := NewDeploymentAPI(fakeClient).GetDeploymentByName(ctx, "test-namespace", "web")
+		require.Error(t, err)
+		assert.True(t, apierrors.IsTooManyRequests(err))
+		assert.Equal(t, 1, getCalls)
+	})
+}
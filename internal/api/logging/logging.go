@@ -0,0 +1,178 @@
+// Package logging emits the structured debug logs of the resource APIs.
+// Each API call is traced by a Call recording the resource, namespace, selectors, pages,
+// items, duration and error class of the requests made for it.
+//
+// Only request metadata is logged. Continue tokens, error messages, which may embed request
+// URLs, and object contents are never logged, so credentials and secrets cannot leak.
+package logging
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	api "github.com/kaudit/k8s_client"
+	"github.com/kaudit/k8s_client/internal/api/classify"
+)
+
+// Call traces a single call of a resource API. A Call without a logger does nothing.
+type Call struct {
+	logger *slog.Logger
+	start  time.Time
+	op     string
+	attrs  []slog.Attr
+	pages  int
+}
+
+// Start begins tracing operation op, e.g. "get" or "list", on resources of kind in namespace.
+// An empty namespace is omitted, as is any attribute of attrs with an empty string value.
+func Start(logger *slog.Logger, op, kind, namespace string, attrs ...slog.Attr) *Call {
+	c := &Call{logger: logger, start: time.Now(), op: op}
+	if logger == nil {
+		return c
+	}
+
+	c.attrs = append(c.attrs, slog.String("kind", kind))
+	if namespace != "" {
+		c.attrs = append(c.attrs, slog.String("namespace", namespace))
+	}
+
+	for _, attr := range attrs {
+		if attr.Value.Kind() == slog.KindString && attr.Value.String() == "" {
+			continue
+		}
+
+		c.attrs = append(c.attrs, attr)
+	}
+
+	return c
+}
+
+// Selectors returns the attributes describing the selectors and page size of a list call.
+func Selectors(labelSelector, fieldSelector string, limit int64) []slog.Attr {
+	attrs := []slog.Attr{
+		slog.String("label_selector", labelSelector),
+		slog.String("field_selector", fieldSelector),
+	}
+	if limit > 0 {
+		attrs = append(attrs, slog.Int64("limit", limit))
+	}
+
+	return attrs
+}
+
+// Page logs a page of a list call that took since start, returned items and, unless err is
+// non-nil, announced more pages if more is true.
+func (c *Call) Page(ctx context.Context, start time.Time, items int, more bool, err error) {
+	c.pages++
+
+	if !c.enabled(ctx) {
+		return
+	}
+
+	attrs := append(c.common(err),
+		slog.Int("page", c.pages),
+		slog.Int("items", items),
+		slog.Bool("more", more),
+		slog.Duration("duration", time.Since(start)),
+	)
+
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "kubernetes list page", attrs...)
+}
+
+// Done logs the completion of the call with the number of items it returned.
+// The number of pages is included for calls that fetched pages.
+func (c *Call) Done(ctx context.Context, items int, err error) {
+	if !c.enabled(ctx) {
+		return
+	}
+
+	attrs := c.common(err)
+	if c.pages > 0 {
+		attrs = append(attrs, slog.Int("pages", c.pages))
+	}
+	attrs = append(attrs, slog.Int("items", items), slog.Duration("duration", time.Since(c.start)))
+
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "kubernetes request", attrs...)
+}
+
+// Fetched logs the completion of a call fetching a single object.
+func (c *Call) Fetched(ctx context.Context, err error) {
+	items := 0
+	if err == nil {
+		items = 1
+	}
+
+	c.Done(ctx, items, err)
+}
+
+// Snapshot logs the completion of a snapshot call. A partial snapshot returned together
+// with err counts its items.
+func Snapshot[T any](ctx context.Context, c *Call, snapshot *api.Snapshot[T], err error) {
+	items := 0
+	if snapshot != nil {
+		items = len(snapshot.Items)
+	}
+
+	c.Done(ctx, items, err)
+}
+
+// End logs the completion of a call that returns no items, such as starting a watch.
+func (c *Call) End(ctx context.Context, err error) {
+	if !c.enabled(ctx) {
+		return
+	}
+
+	attrs := append(c.common(err), slog.Duration("duration", time.Since(c.start)))
+
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "kubernetes request", attrs...)
+}
+
+// enabled reports whether the call logs anything.
+func (c *Call) enabled(ctx context.Context) bool {
+	return c.logger != nil && c.logger.Enabled(ctx, slog.LevelDebug)
+}
+
+// common returns a fresh slice with the operation, the attributes of the call and, if err is
+// non-nil, its class.
+func (c *Call) common(err error) []slog.Attr {
+	attrs := make([]slog.Attr, 0, len(c.attrs)+6)
+	attrs = append(attrs, slog.String("op", c.op))
+	attrs = append(attrs, c.attrs...)
+
+	if err != nil {
+		attrs = append(attrs, slog.String("error_class", Class(err)))
+	}
+
+	return attrs
+}
+
+// Class returns a short name for the category of err, e.g. "not_found" or "forbidden",
+// or "unknown" if err fits none of the categories of the api package.
+func Class(err error) string {
+	classes := []struct {
+		category error
+		name     string
+	}{
+		{api.ErrNotFound, "not_found"},
+		{api.ErrUnauthorized, "unauthorized"},
+		{api.ErrForbidden, "forbidden"},
+		{api.ErrConflict, "conflict"},
+		{api.ErrInvalidInput, "invalid_input"},
+		{api.ErrTimeout, "timeout"},
+	}
+
+	category := classify.Category(err)
+	for _, c := range classes {
+		if errors.Is(err, c.category) || errors.Is(category, c.category) {
+			return c.name
+		}
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return "canceled"
+	}
+
+	return "unknown"
+}
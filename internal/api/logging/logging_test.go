@@ -0,0 +1,152 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	api "github.com/kaudit/k8s_client"
+)
+
+// capture returns a debug logger writing JSON records to the returned function's result.
+func capture(level slog.Level) (*slog.Logger, func(t *testing.T) []map[string]any) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: level}))
+
+	return logger, func(t *testing.T) []map[string]any {
+		t.Helper()
+
+		var records []map[string]any
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			if line == "" {
+				continue
+			}
+
+			var record map[string]any
+			require.NoError(t, json.Unmarshal([]byte(line), &record))
+			records = append(records, record)
+		}
+
+		return records
+	}
+}
+
+func TestCall(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("logs pages and summary of a list", func(t *testing.T) {
+		logger, records := capture(slog.LevelDebug)
+
+		call := Start(logger, "list", "Pod", "audit", Selectors("app=web", "", 50)...)
+		call.Page(ctx, time.Now(), 50, true, nil)
+		call.Page(ctx, time.Now(), 7, false, nil)
+		call.Done(ctx, 57, nil)
+
+		got := records(t)
+		require.Len(t, got, 3)
+
+		assert.Equal(t, "kubernetes list page", got[0]["msg"])
+		assert.Equal(t, "DEBUG", got[0]["level"])
+		assert.Equal(t, "list", got[0]["op"])
+		assert.Equal(t, "Pod", got[0]["kind"])
+		assert.Equal(t, "audit", got[0]["namespace"])
+		assert.Equal(t, "app=web", got[0]["label_selector"])
+		assert.NotContains(t, got[0], "field_selector")
+		assert.EqualValues(t, 50, got[0]["limit"])
+		assert.EqualValues(t, 1, got[0]["page"])
+		assert.Equal(t, true, got[0]["more"])
+		assert.EqualValues(t, 2, got[1]["page"])
+
+		assert.Equal(t, "kubernetes request", got[2]["msg"])
+		assert.EqualValues(t, 2, got[2]["pages"])
+		assert.EqualValues(t, 57, got[2]["items"])
+		assert.Contains(t, got[2], "duration")
+		assert.NotContains(t, got[2], "error_class")
+	})
+
+	t.Run("logs error class but not error message", func(t *testing.T) {
+		logger, records := capture(slog.LevelDebug)
+		err := apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "web", errors.New("secret-detail"))
+
+		call := Start(logger, "get", "Pod", "audit", slog.String("name", "web"))
+		call.Fetched(ctx, err)
+
+		got := records(t)
+		require.Len(t, got, 1)
+		assert.Equal(t, "web", got[0]["name"])
+		assert.Equal(t, "forbidden", got[0]["error_class"])
+		assert.EqualValues(t, 0, got[0]["items"])
+		assert.NotContains(t, fmt.Sprint(got[0]), "secret-detail")
+	})
+
+	t.Run("logs snapshot and watch", func(t *testing.T) {
+		logger, records := capture(slog.LevelDebug)
+
+		Snapshot(ctx, Start(logger, "snapshot", "Namespace", ""), &api.Snapshot[string]{Items: []string{"a", "b"}}, nil)
+		Snapshot[string](ctx, Start(logger, "snapshot", "Namespace", ""), nil, context.DeadlineExceeded)
+		Start(logger, "watch", "Namespace", "").End(ctx, nil)
+
+		got := records(t)
+		require.Len(t, got, 3)
+		assert.NotContains(t, got[0], "namespace")
+		assert.EqualValues(t, 2, got[0]["items"])
+		assert.Equal(t, "timeout", got[1]["error_class"])
+		assert.EqualValues(t, 0, got[1]["items"])
+		assert.Equal(t, "watch", got[2]["op"])
+		assert.NotContains(t, got[2], "items")
+	})
+
+	t.Run("logs nothing above debug level", func(t *testing.T) {
+		logger, records := capture(slog.LevelInfo)
+
+		call := Start(logger, "list", "Pod", "audit")
+		call.Page(ctx, time.Now(), 1, false, nil)
+		call.Done(ctx, 1, nil)
+
+		assert.Empty(t, records(t))
+	})
+
+	t.Run("does nothing without logger", func(t *testing.T) {
+		call := Start(nil, "list", "Pod", "audit")
+
+		assert.NotPanics(t, func() {
+			call.Page(ctx, time.Now(), 1, false, nil)
+			call.Done(ctx, 1, nil)
+			call.End(ctx, nil)
+		})
+	})
+}
+
+func TestClass(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "Not found", err: apierrors.NewNotFound(schema.GroupResource{Resource: "pods"}, "web"), want: "not_found"},
+		{name: "Unauthorized", err: apierrors.NewUnauthorized("expired"), want: "unauthorized"},
+		{name: "Conflict", err: apierrors.NewConflict(schema.GroupResource{Resource: "pods"}, "web", errors.New("x")), want: "conflict"},
+		{name: "Bad request", err: apierrors.NewBadRequest("bad selector"), want: "invalid_input"},
+		{name: "Classified error", err: &api.Error{Category: api.ErrInvalidInput, Err: errors.New("x")}, want: "invalid_input"},
+		{name: "Deadline", err: fmt.Errorf("wrapped: %w", context.DeadlineExceeded), want: "timeout"},
+		{name: "Canceled", err: context.Canceled, want: "canceled"},
+		{name: "Other", err: errors.New("connection refused"), want: "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Class(tt.err))
+		})
+	}
+}
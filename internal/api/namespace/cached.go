@@ -0,0 +1,166 @@
+package namespace
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kaudit/val"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	api "github.com/kaudit/k8s_client"
+	"github.com/kaudit/k8s_client/internal/api/cache"
+	"github.com/kaudit/k8s_client/internal/api/classify"
+	"github.com/kaudit/k8s_client/internal/api/options"
+	"github.com/kaudit/k8s_client/selector"
+)
+
+// CachedNamespaceAPI serves namespace reads from a shared informer cache.
+// Operations that need the API server, such as snapshots and watches, are delegated
+// to the embedded NamespaceAPI.
+type CachedNamespaceAPI struct {
+	*NamespaceAPI
+	lister corelisters.NamespaceLister
+}
+
+// NewCachedNamespaceAPI creates a new CachedNamespaceAPI instance backed by the namespace
+// informer of the given factory.
+// The informer is registered with the factory; the caller is responsible for starting it.
+// It returns an implementation of the api.NamespaceAPI interface.
+func NewCachedNamespaceAPI(client kubernetes.Interface, factory informers.SharedInformerFactory,
+	opts ...options.Option) api.NamespaceAPI {
+
+	return &CachedNamespaceAPI{
+		NamespaceAPI: newNamespaceAPI(client, opts...),
+		lister:       factory.Core().V1().Namespaces().Lister(),
+	}
+}
+
+// GetNamespaceByName retrieves a specific Namespace by name from the informer cache.
+//
+// Parameters:
+//   - ctx: Unused; kept for interface compatibility.
+//   - name: Name of the namespace (must be non-empty).
+//
+// Returns the name of the cached namespace or an error if not found or invalid.
+func (c *CachedNamespaceAPI) GetNamespaceByName(_ context.Context, name string) (string, error) {
+	if err := val.ValidateWithTag(name, "required"); err != nil {
+		return "", classify.Invalid(kind, "", name, fmt.Errorf("invalid namespace name: %w", err))
+	}
+
+	ns, err := c.lister.Get(name)
+	if err != nil {
+		return "", classify.Wrap(kind, "", name, fmt.Errorf("failed to get namespace %q: %w", name, err))
+	}
+
+	return ns.Name, nil
+}
+
+// ListNamespaces lists all cached namespaces.
+// The timeout and limit are validated for interface compatibility but not used.
+//
+// Returns all namespace names sorted alphabetically or an error if validation fails.
+func (c *CachedNamespaceAPI) ListNamespaces(_ context.Context, timeoutSeconds time.Duration,
+	limit int64) ([]string, error) {
+
+	if err := validateInput(timeoutSeconds, limit); err != nil {
+		return nil, err
+	}
+
+	return c.list(labels.Everything(), fields.Everything())
+}
+
+// ListNamespacesByLabel lists cached namespaces by label selector.
+// The timeout and limit are validated for interface compatibility but not used.
+//
+// Returns all matching namespace names sorted alphabetically or an error if validation fails.
+func (c *CachedNamespaceAPI) ListNamespacesByLabel(_ context.Context, labelSelector string,
+	timeoutSeconds time.Duration, limit int64) ([]string, error) {
+
+	if err := validateInput(timeoutSeconds, limit); err != nil {
+		return nil, err
+	}
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, classify.Invalid(kind, "", "", fmt.Errorf("invalid label selector: %w", err))
+	}
+
+	parsed, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, classify.Invalid(kind, "", "", fmt.Errorf("invalid label selector: %w", err))
+	}
+
+	return c.list(parsed, fields.Everything())
+}
+
+// ListNamespacesByField lists cached namespaces by field selector.
+// The timeout and limit are validated for interface compatibility but not used.
+//
+// Returns all matching namespace names sorted alphabetically or an error if validation fails.
+func (c *CachedNamespaceAPI) ListNamespacesByField(_ context.Context, fieldSelector string,
+	timeoutSeconds time.Duration, limit int64) ([]string, error) {
+
+	if err := validateInput(timeoutSeconds, limit); err != nil {
+		return nil, err
+	}
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, classify.Invalid(kind, "", "", fmt.Errorf("invalid field selector: %w", err))
+	}
+
+	parsed, err := cache.Fields(selector.Namespaces, fieldSelector)
+	if err != nil {
+		return nil, classify.Invalid(kind, "", "", fmt.Errorf("invalid field selector: %w", err))
+	}
+
+	return c.list(labels.Everything(), parsed)
+}
+
+// ListNamespacesByQuery lists cached namespaces by combined query.
+// Queries pinned to a resourceVersion are delegated to the API server; otherwise the
+// timeout and limit are validated for interface compatibility but not used.
+//
+// Returns all matching namespace names sorted alphabetically or an error if validation fails.
+func (c *CachedNamespaceAPI) ListNamespacesByQuery(ctx context.Context, query api.ListQuery) ([]string, error) {
+	if !cache.Servable(query) {
+		return c.NamespaceAPI.ListNamespacesByQuery(ctx, query)
+	}
+	if err := val.ValidateStruct(query); err != nil {
+		return nil, classify.Invalid(kind, "", "", fmt.Errorf("invalid list query: %w", err))
+	}
+
+	labelSelector, fieldSelector, err := cache.Selectors(selector.Namespaces, query)
+	if err != nil {
+		return nil, classify.Invalid(kind, "", "", err)
+	}
+
+	return c.list(labelSelector, fieldSelector)
+}
+
+// list returns the names of the cached namespaces matching both selectors.
+func (c *CachedNamespaceAPI) list(labelSelector labels.Selector, fieldSelector fields.Selector) ([]string, error) {
+	namespaces, err := c.lister.List(labelSelector)
+	if err != nil {
+		return nil, classify.Wrap(kind, "", "", fmt.Errorf("failed to list cached namespaces: %w", err))
+	}
+
+	items := cache.Items(namespaces, fieldSelector, namespaceFields)
+
+	result := make([]string, 0, len(items))
+	for _, ns := range items {
+		result = append(result, ns.Name)
+	}
+
+	return result, nil
+}
+
+// namespaceFields returns the selectable fields of a namespace as evaluated by the API server.
+func namespaceFields(ns *corev1.Namespace) fields.Set {
+	set := cache.ObjectMetaFields(ns)
+	set["status.phase"] = string(ns.Status.Phase)
+
+	return set
+}
@@ -0,0 +1,130 @@
+package namespace
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+
+	api "github.com/kaudit/k8s_client"
+	"github.com/kaudit/k8s_client/selector"
+)
+
+func newSyncedCachedNamespaceAPI(t *testing.T, objects ...*corev1.Namespace) *CachedNamespaceAPI {
+	t.Helper()
+
+	fakeClient := fake.NewClientset()
+	for _, obj := range objects {
+		_, err := fakeClient.CoreV1().Namespaces().Create(context.Background(), obj, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	factory := informers.NewSharedInformerFactory(fakeClient, 0)
+	namespaceAPI := NewCachedNamespaceAPI(fakeClient, factory)
+
+	stopCh := make(chan struct{})
+	t.Cleanup(func() {
+		close(stopCh)
+		factory.Shutdown()
+	})
+	factory.Start(stopCh)
+	for _, synced := range factory.WaitForCacheSync(stopCh) {
+		require.True(t, synced)
+	}
+
+	impl, ok := namespaceAPI.(*CachedNamespaceAPI)
+	require.True(t, ok)
+
+	return impl
+}
+
+func TestCachedNamespaceAPI(t *testing.T) {
+	namespaceAPI := newSyncedCachedNamespaceAPI(t,
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-b", Labels: map[string]string{"team": "b"}},
+			Status:     corev1.NamespaceStatus{Phase: corev1.NamespaceTerminating},
+		},
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"team": "a"}},
+			Status:     corev1.NamespaceStatus{Phase: corev1.NamespaceActive},
+		},
+	)
+	ctx := context.Background()
+
+	t.Run("Get cached namespace", func(t *testing.T) {
+		name, err := namespaceAPI.GetNamespaceByName(ctx, "team-a")
+
+		require.NoError(t, err)
+		assert.Equal(t, "team-a", name)
+	})
+
+	t.Run("Get missing namespace", func(t *testing.T) {
+		name, err := namespaceAPI.GetNamespaceByName(ctx, "missing")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get namespace")
+		assert.ErrorIs(t, err, api.ErrNotFound)
+		assert.Empty(t, name)
+	})
+
+	t.Run("List all sorted by name", func(t *testing.T) {
+		names, err := namespaceAPI.ListNamespaces(ctx, 2*time.Second, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"team-a", "team-b"}, names)
+	})
+
+	t.Run("List by label", func(t *testing.T) {
+		names, err := namespaceAPI.ListNamespacesByLabel(ctx, "team=b", 2*time.Second, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"team-b"}, names)
+	})
+
+	t.Run("List by field", func(t *testing.T) {
+		names, err := namespaceAPI.ListNamespacesByField(ctx, "status.phase=Active", 2*time.Second, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"team-a"}, names)
+	})
+
+	t.Run("List by query combining selectors", func(t *testing.T) {
+		names, err := namespaceAPI.ListNamespacesByQuery(ctx, api.ListQuery{
+			LabelSelector:  "team=a",
+			FieldSelector:  "status.phase=Active",
+			TimeoutSeconds: 2 * time.Second,
+			Limit:          1,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"team-a"}, names)
+	})
+
+	t.Run("Invalid input", func(t *testing.T) {
+		_, err := namespaceAPI.ListNamespaces(ctx, time.Millisecond, 1)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid timeout")
+	})
+
+	t.Run("Unsupported field", func(t *testing.T) {
+		for _, fieldSelector := range []string{"metadata.namespace=x", "metadata.namespace!=x"} {
+			_, err := namespaceAPI.ListNamespacesByField(ctx, fieldSelector, 2*time.Second, 1)
+			require.ErrorIs(t, err, api.ErrInvalidInput)
+			require.ErrorIs(t, err, selector.ErrUnsupportedField)
+
+			_, err = namespaceAPI.ListNamespacesByQuery(ctx, api.ListQuery{
+				FieldSelector:  fieldSelector,
+				TimeoutSeconds: 2 * time.Second,
+				Limit:          1,
+			})
+			require.ErrorIs(t, err, api.ErrInvalidInput)
+			require.ErrorIs(t, err, selector.ErrUnsupportedField)
+		}
+	})
+}
@@ -5,26 +5,52 @@ package namespace
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/kaudit/val"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/metadata"
 
 	api "github.com/kaudit/k8s_client"
+	"github.com/kaudit/k8s_client/internal/api/classify"
+	"github.com/kaudit/k8s_client/internal/api/logging"
+	"github.com/kaudit/k8s_client/internal/api/options"
+	"github.com/kaudit/k8s_client/internal/api/pager"
+	"github.com/kaudit/k8s_client/internal/api/retry"
+	"github.com/kaudit/k8s_client/internal/api/watcher"
 )
 
+// kind is the resource kind reported in returned *api.Error values.
+const kind = "Namespace"
+
 // NamespaceAPI provides high-level methods for retrieving Kubernetes namespaces.
 // It handles input validation and supports pagination for list operations.
 type NamespaceAPI struct {
-	client kubernetes.Interface
+	client   kubernetes.Interface
+	metadata metadata.Interface
+	logger   *slog.Logger
+	retry    api.RetryPolicy
 }
 
 // NewNamespaceAPI creates a new NamespaceAPI instance using the provided Kubernetes client.
+// Optional settings such as the metadata client are supplied through opts.
 // It returns an implementation of the api.NamespaceAPI interface.
-func NewNamespaceAPI(client kubernetes.Interface) api.NamespaceAPI {
+func NewNamespaceAPI(client kubernetes.Interface, opts ...options.Option) api.NamespaceAPI {
+	return newNamespaceAPI(client, opts...)
+}
+
+// newNamespaceAPI creates a NamespaceAPI from the client and the resolved options.
+func newNamespaceAPI(client kubernetes.Interface, opts ...options.Option) *NamespaceAPI {
+	o := options.Apply(opts...)
+
 	return &NamespaceAPI{
-		client: client,
+		client:   client,
+		metadata: o.Metadata,
+		logger:   o.Logger,
+		retry:    o.Retry,
 	}
 }
 
@@ -37,12 +63,20 @@ func NewNamespaceAPI(client kubernetes.Interface) api.NamespaceAPI {
 // Returns the matched *corev1.Namespace or an error if not found or invalid.
 func (n *NamespaceAPI) GetNamespaceByName(ctx context.Context, name string) (string, error) {
 	if err := val.ValidateWithTag(name, "required"); err != nil {
-		return "", fmt.Errorf("invalid namespace name: %w", err)
+		return "", classify.Invalid(kind, "", name, fmt.Errorf("invalid namespace name: %w", err))
 	}
 
-	ns, err := n.client.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	call := logging.Start(n.logger, "get", kind, "", slog.String("name", name))
+
+	var ns *corev1.Namespace
+	err := retry.Do(ctx, n.retry, func() error {
+		var err error
+		ns, err = n.client.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+		return err
+	})
+	call.Fetched(ctx, err)
 	if err != nil {
-		return "", fmt.Errorf("failed to get namespace %q: %w", name, err)
+		return "", classify.Wrap(kind, "", name, fmt.Errorf("failed to get namespace %q: %w", name, err))
 	}
 	return ns.Name, nil
 }
@@ -86,7 +120,7 @@ func (n *NamespaceAPI) ListNamespacesByLabel(ctx context.Context, labelSelector
 		return nil, err
 	}
 	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
-		return nil, fmt.Errorf("invalid label selector: %w", err)
+		return nil, classify.Invalid(kind, "", "", fmt.Errorf("invalid label selector: %w", err))
 	}
 
 	seconds := int64(timeoutSeconds.Seconds())
@@ -116,7 +150,7 @@ func (n *NamespaceAPI) ListNamespacesByField(ctx context.Context, fieldSelector
 		return nil, err
 	}
 	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
-		return nil, fmt.Errorf("invalid field selector: %w", err)
+		return nil, classify.Invalid(kind, "", "", fmt.Errorf("invalid field selector: %w", err))
 	}
 
 	seconds := int64(timeoutSeconds.Seconds())
@@ -130,16 +164,137 @@ func (n *NamespaceAPI) ListNamespacesByField(ctx context.Context, fieldSelector
 	return n.loopForResult(ctx, opts)
 }
 
+// ListNamespacesByQuery lists namespaces using a combined query with pagination support.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control.
+//   - query: Query with optional label and field selectors applied together, optional
+//     resourceVersion constraints, timeout and page limit.
+//
+// Returns all matching namespaces across all pages or an error if validation fails or API calls fail.
+func (n *NamespaceAPI) ListNamespacesByQuery(ctx context.Context, query api.ListQuery) ([]string, error) {
+	if err := val.ValidateStruct(query); err != nil {
+		return nil, classify.Invalid(kind, "", "", fmt.Errorf("invalid list query: %w", err))
+	}
+
+	return n.loopForResult(ctx, query.ListOptions())
+}
+
+// ListNamespacesSnapshot lists namespaces at a single resourceVersion with pagination support.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control.
+//   - opts: Snapshot options with optional label and field selectors, timeout, page limit
+//     and the policy applied when a continue token expires.
+//
+// Returns a snapshot with all matching namespace names and the resourceVersion of the first page.
+// With api.ExpiredContinuePartial, an expired continue token yields the partial snapshot
+// together with an error wrapping *api.ContinueExpiredError.
+func (n *NamespaceAPI) ListNamespacesSnapshot(ctx context.Context,
+	opts api.SnapshotOptions) (*api.Snapshot[string], error) {
+
+	if err := val.ValidateStruct(opts); err != nil {
+		return nil, classify.Invalid(kind, "", "", fmt.Errorf("invalid snapshot options: %w", err))
+	}
+
+	call := logging.Start(n.logger, "snapshot", kind, "",
+		logging.Selectors(opts.LabelSelector, opts.FieldSelector, opts.Limit)...)
+
+	snapshot, err := pager.Snapshot(ctx, opts, pager.Logged(call, pager.Retrying(n.retry, n.listPage)))
+	logging.Snapshot(ctx, call, snapshot, err)
+	if err != nil {
+		return snapshot, classify.Wrap(kind, "", "", fmt.Errorf("failed to list namespaces snapshot: %w", err))
+	}
+
+	return snapshot, nil
+}
+
+// WatchNamespaces watches namespaces and delivers typed change events.
+//
+// Parameters:
+//   - ctx: Context controlling the watch; the returned channel is closed when it is done.
+//   - opts: Watch options with optional label and field selectors and a starting resourceVersion.
+//
+// Unlike list operations, events carry full namespace objects so that changes are observable.
+// The watch follows bookmarks and resumes from the last observed resourceVersion after a
+// disconnect. Returns the event channel or an error if validation or the initial watch call fails.
+func (n *NamespaceAPI) WatchNamespaces(ctx context.Context,
+	opts api.WatchOptions) (<-chan api.WatchEvent[*corev1.Namespace], error) {
+
+	if err := val.ValidateStruct(opts); err != nil {
+		return nil, classify.Invalid(kind, "", "", fmt.Errorf("invalid watch options: %w", err))
+	}
+
+	call := logging.Start(n.logger, "watch", kind, "",
+		logging.Selectors(opts.LabelSelector, opts.FieldSelector, 0)...)
+
+	events, err := watcher.Watch[*corev1.Namespace](ctx, opts, n.client.CoreV1().Namespaces().Watch)
+	call.End(ctx, err)
+	if err != nil {
+		return nil, classify.Wrap(kind, "", "", fmt.Errorf("failed to watch namespaces: %w", err))
+	}
+
+	return events, nil
+}
+
+// ListNamespacesMetadata lists the metadata of namespaces with pagination support.
+// Only object metadata (names, labels, annotations, owners, timestamps) is transferred,
+// which keeps responses small for inventory purposes.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control.
+//   - labelSelector: Optional Kubernetes label selector; when empty, all namespaces are listed.
+//   - timeoutSeconds: Timeout duration for the API call (must be at least 1s).
+//   - limit: Maximum number of results per page (must be greater than 0).
+//
+// Returns the metadata of all matching namespaces across all pages or an error if validation
+// fails, no metadata client is configured, or API calls fail.
+func (n *NamespaceAPI) ListNamespacesMetadata(ctx context.Context, labelSelector string,
+	timeoutSeconds time.Duration, limit int64) ([]metav1.PartialObjectMetadata, error) {
+
+	if err := validateInput(timeoutSeconds, limit); err != nil {
+		return nil, err
+	}
+	if err := val.ValidateWithTag(labelSelector, "omitempty,k8s_label_selector"); err != nil {
+		return nil, classify.Invalid(kind, "", "", fmt.Errorf("invalid label selector: %w", err))
+	}
+	if n.metadata == nil {
+		return nil, classify.Wrap(kind, "", "", options.ErrNoMetadataClient)
+	}
+
+	seconds := int64(timeoutSeconds.Seconds())
+
+	opts := metav1.ListOptions{
+		LabelSelector:  labelSelector,
+		Limit:          limit,
+		TimeoutSeconds: &seconds,
+	}
+
+	resource := n.metadata.Resource(corev1.SchemeGroupVersion.WithResource("namespaces"))
+
+	call := logging.Start(n.logger, "list_metadata", kind, "",
+		logging.Selectors(labelSelector, "", limit)...)
+	fetch := pager.Retrying(n.retry, pager.MetadataPage(resource))
+
+	result, err := pager.Collect(ctx, opts, pager.Logged(call, fetch))
+	call.Done(ctx, len(result), err)
+	if err != nil {
+		return nil, classify.Wrap(kind, "", "", fmt.Errorf("failed to list namespaces metadata: %w", err))
+	}
+
+	return result, nil
+}
+
 // validateInput validates common input parameters for list operations.
 // It checks that timeout is at least 1 second and limit is positive.
 // Returns an error with detailed information if validation fails.
 func validateInput(timeoutSeconds time.Duration, limit int64) error {
 	if err := val.ValidateWithTag(timeoutSeconds, "required,min=1s"); err != nil {
-		return fmt.Errorf("invalid timeout: %w", err)
+		return classify.Invalid(kind, "", "", fmt.Errorf("invalid timeout: %w", err))
 	}
 
 	if err := val.ValidateWithTag(limit, "required,gt=0"); err != nil {
-		return fmt.Errorf("invalid limit: %w", err)
+		return classify.Invalid(kind, "", "", fmt.Errorf("invalid limit: %w", err))
 	}
 
 	return nil
@@ -154,24 +309,33 @@ func validateInput(timeoutSeconds time.Duration, limit int64) error {
 //
 // Returns the complete list of namespaces across all pages or an error if any API call fails.
 func (n *NamespaceAPI) loopForResult(ctx context.Context, opts metav1.ListOptions) ([]string, error) {
-	var result []string
+	call := logging.Start(n.logger, "list", kind, "",
+		logging.Selectors(opts.LabelSelector, opts.FieldSelector, opts.Limit)...)
 
-	for {
-		list, err := n.client.CoreV1().Namespaces().List(ctx, opts)
-		if err != nil {
-			return nil, fmt.Errorf("failed to list namespaces: %w", err)
-		}
+	result, err := pager.Collect(ctx, opts, pager.Logged(call, pager.Retrying(n.retry, n.listPage)))
+	call.Done(ctx, len(result), err)
+	if err != nil {
+		return nil, classify.Wrap(kind, "", "", fmt.Errorf("failed to list namespaces: %w", err))
+	}
 
-		for _, ns := range list.Items {
-			result = append(result, ns.Name)
-		}
+	return result, nil
+}
 
-		if list.Continue == "" {
-			break
-		}
+// listPage fetches a single page of namespace names as a pager.PageFunc.
+func (n *NamespaceAPI) listPage(ctx context.Context, opts metav1.ListOptions) (pager.Page[string], error) {
+	list, err := n.client.CoreV1().Namespaces().List(ctx, opts)
+	if err != nil {
+		return pager.Page[string]{}, err
+	}
 
-		opts.Continue = list.Continue
+	names := make([]string, 0, len(list.Items))
+	for _, ns := range list.Items {
+		names = append(names, ns.Name)
 	}
 
-	return result, nil
+	return pager.Page[string]{
+		Items:           names,
+		Continue:        list.Continue,
+		ResourceVersion: list.ResourceVersion,
+	}, nil
 }
@@ -2,14 +2,23 @@ package namespace
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes/fake"
+	metadatafake "k8s.io/client-go/metadata/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	api "github.com/kaudit/k8s_client"
+	"github.com/kaudit/k8s_client/internal/api/options"
 )
 
 func TestNamespaceAPI_New(t *testing.T) {
@@ -100,6 +109,7 @@ func TestNamespaceAPI_GetNamespaceByName(t *testing.T) {
 		namespaceName string
 		wantErr       bool
 		errorContains string
+		wantErrIs     error
 	}{
 		{
 			name:          "Successfully get namespace",
@@ -111,12 +121,14 @@ func TestNamespaceAPI_GetNamespaceByName(t *testing.T) {
 			namespaceName: "",
 			wantErr:       true,
 			errorContains: "invalid namespace name",
+			wantErrIs:     api.ErrInvalidInput,
 		},
 		{
 			name:          "Namespace not found",
 			namespaceName: "nonexistent-namespace",
 			wantErr:       true,
 			errorContains: "failed to get namespace",
+			wantErrIs:     api.ErrNotFound,
 		},
 	}
 
@@ -130,6 +142,10 @@ func TestNamespaceAPI_GetNamespaceByName(t *testing.T) {
 				if tt.errorContains != "" {
 					assert.Contains(t, err.Error(), tt.errorContains)
 				}
+				require.ErrorIs(t, err, tt.wantErrIs)
+				var apiErr *api.Error
+				require.ErrorAs(t, err, &apiErr)
+				assert.Equal(t, "Namespace", apiErr.Kind)
 				assert.Empty(t, namespaceName)
 			} else {
 				require.NoError(t, err)
@@ -405,3 +421,355 @@ func TestNamespaceAPI_ListNamespacesByField(t *testing.T) {
 		})
 	}
 }
+
+func TestNamespaceAPI_ListNamespacesByQuery(t *testing.T) {
+	// Serve two pages and record the options of every request
+	fakeClient := fake.NewClientset()
+	var requests []metav1.ListOptions
+	fakeClient.PrependReactor("list", "namespaces", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		opts := action.(k8stesting.ListActionImpl).GetListOptions()
+		requests = append(requests, opts)
+		if opts.Continue == "" {
+			return true, &corev1.NamespaceList{
+				ListMeta: metav1.ListMeta{ResourceVersion: "10", Continue: "token"},
+				Items:    []corev1.Namespace{{ObjectMeta: metav1.ObjectMeta{Name: "a", Labels: map[string]string{"team": "a"}}}},
+			}, nil
+		}
+		return true, &corev1.NamespaceList{
+			ListMeta: metav1.ListMeta{ResourceVersion: "10"},
+			Items:    []corev1.Namespace{{ObjectMeta: metav1.ObjectMeta{Name: "b", Labels: map[string]string{"team": "a"}}}},
+		}, nil
+	})
+
+	namespaceAPI := NewNamespaceAPI(fakeClient)
+
+	tests := []struct {
+		name          string
+		query         api.ListQuery
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name: "Combined selectors with resourceVersion",
+			query: api.ListQuery{
+				LabelSelector:        "team=a",
+				FieldSelector:        "status.phase=Active",
+				ResourceVersion:      "5",
+				ResourceVersionMatch: metav1.ResourceVersionMatchNotOlderThan,
+				TimeoutSeconds:       2 * time.Second,
+				Limit:                1,
+			},
+			wantErr: false,
+		},
+		{
+			name:          "Invalid label selector",
+			query:         api.ListQuery{LabelSelector: "app in (web", TimeoutSeconds: 2 * time.Second, Limit: 1},
+			wantErr:       true,
+			errorContains: "invalid list query",
+		},
+		{
+			name:          "Invalid field selector",
+			query:         api.ListQuery{FieldSelector: "spec.unknown=x", TimeoutSeconds: 2 * time.Second, Limit: 1},
+			wantErr:       true,
+			errorContains: "invalid list query",
+		},
+		{
+			name: "Match without resourceVersion",
+			query: api.ListQuery{
+				ResourceVersionMatch: metav1.ResourceVersionMatchExact,
+				TimeoutSeconds:       2 * time.Second,
+				Limit:                1,
+			},
+			wantErr:       true,
+			errorContains: "invalid list query",
+		},
+		{
+			name:          "Invalid limit",
+			query:         api.ListQuery{TimeoutSeconds: 2 * time.Second},
+			wantErr:       true,
+			errorContains: "invalid list query",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests = nil
+			ctx := context.Background()
+			names, err := namespaceAPI.ListNamespacesByQuery(ctx, tt.query)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, names)
+				assert.Empty(t, requests)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, []string{"a", "b"}, names)
+			require.Len(t, requests, 2)
+			assert.Equal(t, tt.query.LabelSelector, requests[0].LabelSelector)
+			assert.Equal(t, tt.query.FieldSelector, requests[0].FieldSelector)
+			assert.Equal(t, "5", requests[0].ResourceVersion)
+			assert.Equal(t, metav1.ResourceVersionMatchNotOlderThan, requests[0].ResourceVersionMatch)
+			assert.Equal(t, "token", requests[1].Continue)
+			assert.Empty(t, requests[1].ResourceVersion)
+			assert.Empty(t, requests[1].ResourceVersionMatch)
+		})
+	}
+}
+
+func TestNamespaceAPI_ListNamespacesSnapshot(t *testing.T) {
+	// Serve a first page, expire its continue token once and then serve the full list
+	fakeClient := fake.NewClientset()
+	calls := 0
+	fakeClient.PrependReactor("list", "namespaces", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		calls++
+		opts := action.(k8stesting.ListActionImpl).GetListOptions()
+		switch {
+		case calls == 1:
+			return true, &corev1.NamespaceList{
+				ListMeta: metav1.ListMeta{ResourceVersion: "10", Continue: "token"},
+				Items:    []corev1.Namespace{{ObjectMeta: metav1.ObjectMeta{Name: "a"}}},
+			}, nil
+		case opts.Continue != "":
+			return true, nil, apierrors.NewResourceExpired("continue token too old")
+		default:
+			return true, &corev1.NamespaceList{
+				ListMeta: metav1.ListMeta{ResourceVersion: "20"},
+				Items: []corev1.Namespace{
+					{ObjectMeta: metav1.ObjectMeta{Name: "a"}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "b"}},
+				},
+			}, nil
+		}
+	})
+
+	namespaceAPI := NewNamespaceAPI(fakeClient)
+
+	tests := []struct {
+		name          string
+		opts          api.SnapshotOptions
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:    "Restarts after expired continue token",
+			opts:    api.SnapshotOptions{TimeoutSeconds: 2 * time.Second, Limit: 1},
+			wantErr: false,
+		},
+		{
+			name:          "Invalid label selector",
+			opts:          api.SnapshotOptions{LabelSelector: "app in (web", TimeoutSeconds: 2 * time.Second, Limit: 1},
+			wantErr:       true,
+			errorContains: "invalid snapshot options",
+		},
+		{
+			name:          "Invalid limit",
+			opts:          api.SnapshotOptions{TimeoutSeconds: 2 * time.Second},
+			wantErr:       true,
+			errorContains: "invalid snapshot options",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			snapshot, err := namespaceAPI.ListNamespacesSnapshot(ctx, tt.opts)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, snapshot)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "20", snapshot.ResourceVersion)
+			assert.Equal(t, 1, snapshot.Restarts)
+			assert.True(t, snapshot.Complete)
+			assert.Equal(t, []string{"a", "b"}, snapshot.Items)
+		})
+	}
+}
+
+func TestNamespaceAPI_WatchNamespaces(t *testing.T) {
+	fakeClient := fake.NewClientset()
+	namespaceAPI := NewNamespaceAPI(fakeClient)
+
+	t.Run("Delivers added event", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events, err := namespaceAPI.WatchNamespaces(ctx, api.WatchOptions{})
+		require.NoError(t, err)
+
+		_, err = fakeClient.CoreV1().Namespaces().Create(ctx,
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "watched"}}, metav1.CreateOptions{})
+		require.NoError(t, err)
+
+		select {
+		case event := <-events:
+			assert.Equal(t, api.EventAdded, event.Type)
+			assert.Equal(t, "watched", event.Object.Name)
+		case <-time.After(5 * time.Second):
+			t.Fatal("no event received")
+		}
+	})
+
+	t.Run("Invalid label selector", func(t *testing.T) {
+		events, err := namespaceAPI.WatchNamespaces(context.Background(), api.WatchOptions{LabelSelector: "app in (web"})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid watch options")
+		assert.Nil(t, events)
+	})
+}
+
+func TestNamespaceAPI_ListNamespacesMetadata(t *testing.T) {
+	scheme := metadatafake.NewTestScheme()
+	require.NoError(t, metav1.AddMetaToScheme(scheme))
+
+	newMetadata := func(name string, labels map[string]string) *metav1.PartialObjectMetadata {
+		return &metav1.PartialObjectMetadata{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		}
+	}
+	metadataClient := metadatafake.NewSimpleMetadataClient(scheme,
+		newMetadata("web", map[string]string{"app": "web"}),
+		newMetadata("db", map[string]string{"app": "db"}),
+	)
+
+	tests := []struct {
+		name           string
+		api            *NamespaceAPI
+		labelSelector  string
+		timeoutSeconds time.Duration
+		limit          int64
+		expectedNames  []string
+		wantErr        bool
+		errorContains  string
+	}{
+		{
+			name:           "List all metadata",
+			api:            newNamespaceAPI(fake.NewClientset(), options.WithMetadataClient(metadataClient)),
+			timeoutSeconds: 2 * time.Second,
+			limit:          1,
+			expectedNames:  []string{"db", "web"},
+		},
+		{
+			name:           "List metadata by label",
+			api:            newNamespaceAPI(fake.NewClientset(), options.WithMetadataClient(metadataClient)),
+			labelSelector:  "app=web",
+			timeoutSeconds: 2 * time.Second,
+			limit:          1,
+			expectedNames:  []string{"web"},
+		},
+		{
+			name:           "Invalid label selector",
+			api:            newNamespaceAPI(fake.NewClientset(), options.WithMetadataClient(metadataClient)),
+			labelSelector:  "app in (web",
+			timeoutSeconds: 2 * time.Second,
+			limit:          1,
+			wantErr:        true,
+			errorContains:  "invalid label selector",
+		},
+		{
+			name:           "Missing metadata client",
+			api:            newNamespaceAPI(fake.NewClientset()),
+			timeoutSeconds: 2 * time.Second,
+			limit:          1,
+			wantErr:        true,
+			errorContains:  options.ErrNoMetadataClient.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := tt.api.ListNamespacesMetadata(context.Background(), tt.labelSelector,
+				tt.timeoutSeconds, tt.limit)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, items)
+				return
+			}
+
+			require.NoError(t, err)
+			names := make([]string, 0, len(items))
+			for _, item := range items {
+				names = append(names, item.Name)
+			}
+			assert.ElementsMatch(t, tt.expectedNames, names)
+		})
+	}
+}
+
+func TestNamespaceAPI_RetryPolicy(t *testing.T) {
+	// Fail the first calls of every verb and count all attempts
+	fakeClient := fake.NewClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "web", Labels: map[string]string{"app": "web"}}})
+	var getFailures, listFailures, getCalls int
+	var getErr error
+	fakeClient.PrependReactor("get", "namespaces", func(k8stesting.Action) (bool, runtime.Object, error) {
+		getCalls++
+		if getFailures > 0 {
+			getFailures--
+			return true, nil, getErr
+		}
+		return false, nil, nil
+	})
+	fakeClient.PrependReactor("list", "namespaces", func(k8stesting.Action) (bool, runtime.Object, error) {
+		if listFailures > 0 {
+			listFailures--
+			return true, nil, apierrors.NewServiceUnavailable("restarting")
+		}
+		return false, nil, nil
+	})
+
+	policy := api.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	namespaceAPI := NewNamespaceAPI(fakeClient, options.WithRetryPolicy(policy))
+	ctx := context.Background()
+
+	t.Run("Retries transient failures", func(t *testing.T) {
+		getFailures, listFailures, getCalls = 2, 2, 0
+		getErr = apierrors.NewTooManyRequests("slow down", 0)
+
+		got, err := namespaceAPI.GetNamespaceByName(ctx, "web")
+		require.NoError(t, err)
+		assert.Equal(t, "web", got)
+		assert.Equal(t, 3, getCalls)
+
+		items, err := namespaceAPI.ListNamespacesByLabel(ctx, "app=web", 2*time.Second, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"web"}, items)
+	})
+
+	t.Run("Gives up after max attempts", func(t *testing.T) {
+		listFailures = 3
+
+		_, err := namespaceAPI.ListNamespacesByLabel(ctx, "app=web", 2*time.Second, 1)
+		require.Error(t, err)
+		assert.True(t, apierrors.IsServiceUnavailable(err))
+	})
+
+	t.Run("Does not retry permanent errors", func(t *testing.T) {
+		getFailures, getCalls = 3, 0
+		getErr = apierrors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, "web", errors.New("denied"))
+
+		_, err := namespaceAPI.GetNamespaceByName(ctx, "web")
+		require.ErrorIs(t, err, api.ErrForbidden)
+		assert.Equal(t, 1, getCalls)
+	})
+
+	t.Run("Attempts once without policy", func(t *testing.T) {
+		getFailures, getCalls = 1, 0
+		getErr = apierrors.NewTooManyRequests("slow down", 0)
+
+		_, err := NewNamespaceAPI(fakeClient).GetNamespaceByName(ctx, "web")
+		require.Error(t, err)
+		assert.True(t, apierrors.IsTooManyRequests(err))
+		assert.Equal(t, 1, getCalls)
+	})
+}
@@ -0,0 +1,66 @@
+// Package options holds the optional dependencies and settings shared by the resource APIs.
+// Resource constructors accept a list of Option values and resolve them with Apply.
+package options
+
+import (
+	"errors"
+	"log/slog"
+
+	"k8s.io/client-go/metadata"
+
+	api "github.com/kaudit/k8s_client"
+)
+
+// ErrNoMetadataClient is returned by metadata-only list operations of APIs created without
+// a metadata client.
+var ErrNoMetadataClient = errors.New("metadata client not configured")
+
+// Options is the resolved set of optional dependencies of a resource API.
+type Options struct {
+	Metadata       metadata.Interface
+	Logger         *slog.Logger
+	CacheNamespace string
+	Retry          api.RetryPolicy
+}
+
+// Option configures a resource API.
+type Option func(*Options)
+
+// WithMetadataClient sets the metadata client used for metadata-only list operations.
+func WithMetadataClient(client metadata.Interface) Option {
+	return func(o *Options) {
+		o.Metadata = client
+	}
+}
+
+// WithRetryPolicy sets the policy used to retry transient failures of Get calls and list pages.
+func WithRetryPolicy(policy api.RetryPolicy) Option {
+	return func(o *Options) {
+		o.Retry = policy
+	}
+}
+
+// WithLogger sets the logger receiving debug logs of the requests made by the API.
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *Options) {
+		o.Logger = logger
+	}
+}
+
+// WithCacheNamespace declares that the informers of a cached API only hold objects of
+// namespace. Reads of other namespaces are delegated to the API server.
+func WithCacheNamespace(namespace string) Option {
+	return func(o *Options) {
+		o.CacheNamespace = namespace
+	}
+}
+
+// Apply resolves the given options into an Options value.
+func Apply(opts ...Option) Options {
+	var o Options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
@@ -0,0 +1,186 @@
+// Package pager implements the paginated list loops shared by the resource APIs.
+// Resource packages adapt their typed List calls to a PageFunc and let the pager
+// handle continue tokens and expired snapshots.
+package pager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/metadata"
+
+	api "github.com/kaudit/k8s_client"
+	"github.com/kaudit/k8s_client/internal/api/logging"
+	"github.com/kaudit/k8s_client/internal/api/retry"
+)
+
+// Page is a single page of a list response reduced to what the pager needs.
+type Page[T any] struct {
+	Items           []T
+	Continue        string
+	ResourceVersion string
+}
+
+// PageFunc fetches a single page of results for the given list options.
+type PageFunc[T any] func(ctx context.Context, opts metav1.ListOptions) (Page[T], error)
+
+// Collect lists all pages by following continue tokens until the last page.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - opts: List options including selectors, limit, and timeout.
+//   - fetch: Function returning a single page of results.
+//
+// Returns the items of all pages or the first error returned by fetch.
+func Collect[T any](ctx context.Context, opts metav1.ListOptions, fetch PageFunc[T]) ([]T, error) {
+	var result []T
+
+	for {
+		page, err := fetch(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, page.Items...)
+
+		if page.Continue == "" {
+			break
+		}
+
+		// The continue token encodes the resourceVersion of the first page, and the
+		// API server rejects requests combining it with an explicit resourceVersion.
+		opts.Continue = page.Continue
+		opts.ResourceVersion = ""
+		opts.ResourceVersionMatch = ""
+	}
+
+	return result, nil
+}
+
+// Snapshot lists all pages at the resourceVersion of the first page.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - opts: Validated snapshot options providing selectors, limit, timeout and expiry policy.
+//   - fetch: Function returning a single page of results.
+//
+// When a continue token expires (410 Gone), the list is restarted or a partial snapshot
+// is returned together with a *api.ContinueExpiredError, depending on opts.OnExpired.
+// Any other error aborts the list and is returned as is.
+func Snapshot[T any](ctx context.Context, opts api.SnapshotOptions, fetch PageFunc[T]) (*api.Snapshot[T], error) {
+	maxRestarts := opts.MaxRestarts
+	if maxRestarts == 0 {
+		maxRestarts = api.DefaultSnapshotRestarts
+	}
+
+	seconds := int64(opts.TimeoutSeconds.Seconds())
+	listOpts := metav1.ListOptions{
+		LabelSelector:  opts.LabelSelector,
+		FieldSelector:  opts.FieldSelector,
+		Limit:          opts.Limit,
+		TimeoutSeconds: &seconds,
+	}
+
+	snapshot := &api.Snapshot[T]{}
+
+	for {
+		page, err := fetch(ctx, listOpts)
+		if err != nil {
+			if listOpts.Continue == "" || !isExpired(err) {
+				return nil, err
+			}
+
+			expired := &api.ContinueExpiredError{
+				ResourceVersion: snapshot.ResourceVersion,
+				Collected:       len(snapshot.Items),
+				Restarts:        snapshot.Restarts,
+				Err:             err,
+			}
+
+			if opts.OnExpired == api.ExpiredContinuePartial {
+				return snapshot, expired
+			}
+
+			if snapshot.Restarts >= maxRestarts {
+				return nil, fmt.Errorf("restart limit %d reached: %w", maxRestarts, expired)
+			}
+
+			snapshot.Items = nil
+			snapshot.ResourceVersion = ""
+			snapshot.Restarts++
+			listOpts.Continue = ""
+
+			continue
+		}
+
+		if listOpts.Continue == "" {
+			snapshot.ResourceVersion = page.ResourceVersion
+		}
+
+		snapshot.Items = append(snapshot.Items, page.Items...)
+
+		if page.Continue == "" {
+			break
+		}
+
+		listOpts.Continue = page.Continue
+	}
+
+	snapshot.Complete = true
+
+	return snapshot, nil
+}
+
+// MetadataPage returns a PageFunc fetching a single page of object metadata from the given
+// metadata client resource.
+func MetadataPage(resource metadata.ResourceInterface) PageFunc[metav1.PartialObjectMetadata] {
+	return func(ctx context.Context, opts metav1.ListOptions) (Page[metav1.PartialObjectMetadata], error) {
+		list, err := resource.List(ctx, opts)
+		if err != nil {
+			return Page[metav1.PartialObjectMetadata]{}, err
+		}
+
+		return Page[metav1.PartialObjectMetadata]{
+			Items:           list.Items,
+			Continue:        list.Continue,
+			ResourceVersion: list.ResourceVersion,
+		}, nil
+	}
+}
+
+// Retrying returns a PageFunc retrying transient failures of fetch under policy.
+// Each page is retried on its own, so a failure deep into a list does not restart it.
+func Retrying[T any](policy api.RetryPolicy, fetch PageFunc[T]) PageFunc[T] {
+	return func(ctx context.Context, opts metav1.ListOptions) (Page[T], error) {
+		var page Page[T]
+
+		err := retry.Do(ctx, policy, func() error {
+			var err error
+			page, err = fetch(ctx, opts)
+			return err
+		})
+
+		return page, err
+	}
+}
+
+// Logged returns a PageFunc logging each page fetched by fetch, including its retries,
+// as a page of call.
+func Logged[T any](call *logging.Call, fetch PageFunc[T]) PageFunc[T] {
+	return func(ctx context.Context, opts metav1.ListOptions) (Page[T], error) {
+		start := time.Now()
+
+		page, err := fetch(ctx, opts)
+		call.Page(ctx, start, len(page.Items), page.Continue != "", err)
+
+		return page, err
+	}
+}
+
+// isExpired reports whether err signals an expired continue token or resourceVersion.
+func isExpired(err error) bool {
+	return apierrors.IsResourceExpired(err) || apierrors.IsGone(err)
+}
@@ -0,0 +1,195 @@
+package pager
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	api "github.com/kaudit/k8s_client"
+	"github.com/kaudit/k8s_client/internal/api/logging"
+)
+
+// scriptedPages returns a PageFunc replaying the given responses in order and
+// recording the list options of every call.
+func scriptedPages(calls *[]metav1.ListOptions, responses ...func() (Page[string], error)) PageFunc[string] {
+	return func(_ context.Context, opts metav1.ListOptions) (Page[string], error) {
+		*calls = append(*calls, opts)
+		if len(*calls) > len(responses) {
+			return Page[string]{}, errors.New("unexpected call")
+		}
+		return responses[len(*calls)-1]()
+	}
+}
+
+func page(rv, cont string, items ...string) func() (Page[string], error) {
+	return func() (Page[string], error) {
+		return Page[string]{Items: items, Continue: cont, ResourceVersion: rv}, nil
+	}
+}
+
+func failure(err error) func() (Page[string], error) {
+	return func() (Page[string], error) {
+		return Page[string]{}, err
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	expired := apierrors.NewResourceExpired("continue token too old")
+	opts := api.SnapshotOptions{
+		LabelSelector:  "app=web",
+		TimeoutSeconds: 2 * time.Second,
+		Limit:          2,
+	}
+
+	t.Run("collects all pages at first resource version", func(t *testing.T) {
+		var calls []metav1.ListOptions
+		fetch := scriptedPages(&calls, page("100", "c1", "a", "b"), page("100", "", "c"))
+
+		snapshot, err := Snapshot(context.Background(), opts, fetch)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b", "c"}, snapshot.Items)
+		assert.Equal(t, "100", snapshot.ResourceVersion)
+		assert.True(t, snapshot.Complete)
+		assert.Zero(t, snapshot.Restarts)
+		require.Len(t, calls, 2)
+		assert.Equal(t, "app=web", calls[0].LabelSelector)
+		assert.Equal(t, int64(2), calls[0].Limit)
+		assert.Equal(t, "c1", calls[1].Continue)
+	})
+
+	t.Run("restarts after expired continue token", func(t *testing.T) {
+		var calls []metav1.ListOptions
+		fetch := scriptedPages(&calls,
+			page("100", "c1", "a", "b"),
+			failure(expired),
+			page("200", "c2", "a", "b"),
+			page("200", "", "c"),
+		)
+
+		snapshot, err := Snapshot(context.Background(), opts, fetch)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b", "c"}, snapshot.Items)
+		assert.Equal(t, "200", snapshot.ResourceVersion)
+		assert.Equal(t, 1, snapshot.Restarts)
+		assert.True(t, snapshot.Complete)
+		assert.Empty(t, calls[2].Continue)
+	})
+
+	t.Run("returns partial snapshot with typed error", func(t *testing.T) {
+		partialOpts := opts
+		partialOpts.OnExpired = api.ExpiredContinuePartial
+
+		var calls []metav1.ListOptions
+		fetch := scriptedPages(&calls, page("100", "c1", "a", "b"), failure(expired))
+
+		snapshot, err := Snapshot(context.Background(), partialOpts, fetch)
+
+		require.Error(t, err)
+		var expiredErr *api.ContinueExpiredError
+		require.ErrorAs(t, err, &expiredErr)
+		assert.Equal(t, "100", expiredErr.ResourceVersion)
+		assert.Equal(t, 2, expiredErr.Collected)
+		assert.True(t, apierrors.IsResourceExpired(err))
+
+		require.NotNil(t, snapshot)
+		assert.Equal(t, []string{"a", "b"}, snapshot.Items)
+		assert.False(t, snapshot.Complete)
+	})
+
+	t.Run("fails when restart limit is reached", func(t *testing.T) {
+		limitedOpts := opts
+		limitedOpts.MaxRestarts = 1
+
+		var calls []metav1.ListOptions
+		fetch := scriptedPages(&calls,
+			page("100", "c1", "a"),
+			failure(expired),
+			page("200", "c2", "a"),
+			failure(expired),
+		)
+
+		snapshot, err := Snapshot(context.Background(), limitedOpts, fetch)
+
+		require.Error(t, err)
+		assert.Nil(t, snapshot)
+		assert.Contains(t, err.Error(), "restart limit 1 reached")
+		var expiredErr *api.ContinueExpiredError
+		require.ErrorAs(t, err, &expiredErr)
+		assert.Equal(t, 1, expiredErr.Restarts)
+	})
+
+	t.Run("does not recover from other errors", func(t *testing.T) {
+		var calls []metav1.ListOptions
+		forbidden := apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", errors.New("denied"))
+		fetch := scriptedPages(&calls, page("100", "c1", "a"), failure(forbidden))
+
+		snapshot, err := Snapshot(context.Background(), opts, fetch)
+
+		require.Error(t, err)
+		assert.Nil(t, snapshot)
+		assert.True(t, apierrors.IsForbidden(err))
+		assert.Len(t, calls, 2)
+	})
+}
+
+func TestRetrying(t *testing.T) {
+	policy := api.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	unavailable := apierrors.NewServiceUnavailable("restarting")
+
+	t.Run("retries a failing page without restarting the list", func(t *testing.T) {
+		var calls []metav1.ListOptions
+		fetch := scriptedPages(&calls, page("100", "c1", "a"), failure(unavailable), page("100", "", "b"))
+
+		items, err := Collect(context.Background(), metav1.ListOptions{Limit: 1}, Retrying(policy, fetch))
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, items)
+		require.Len(t, calls, 3)
+		assert.Equal(t, "c1", calls[1].Continue)
+		assert.Equal(t, "c1", calls[2].Continue)
+	})
+
+	t.Run("returns permanent errors immediately", func(t *testing.T) {
+		var calls []metav1.ListOptions
+		badRequest := apierrors.NewBadRequest("bad selector")
+		fetch := scriptedPages(&calls, failure(badRequest))
+
+		items, err := Collect(context.Background(), metav1.ListOptions{Limit: 1}, Retrying(policy, fetch))
+
+		require.ErrorIs(t, err, badRequest)
+		assert.Nil(t, items)
+		assert.Len(t, calls, 1)
+	})
+}
+
+func TestLogged(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	call := logging.Start(logger, "list", "Pod", "audit")
+
+	var calls []metav1.ListOptions
+	fetch := scriptedPages(&calls, page("100", "c1", "a", "b"), page("100", "", "c"))
+
+	items, err := Collect(context.Background(), metav1.ListOptions{Limit: 2}, Logged(call, fetch))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, items)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], "page=1 items=2 more=true")
+	assert.Contains(t, lines[1], "page=2 items=1 more=false")
+	assert.NotContains(t, buf.String(), "c1", "continue tokens must not be logged")
+}
@@ -0,0 +1,175 @@
+package pod
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kaudit/val"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	api "github.com/kaudit/k8s_client"
+	"github.com/kaudit/k8s_client/internal/api/cache"
+	"github.com/kaudit/k8s_client/internal/api/classify"
+	"github.com/kaudit/k8s_client/internal/api/options"
+	"github.com/kaudit/k8s_client/selector"
+)
+
+// CachedPodAPI serves pod reads from a shared informer cache.
+// Operations that need the API server, such as snapshots and watches, are delegated
+// to the embedded PodAPI.
+type CachedPodAPI struct {
+	*PodAPI
+	lister corelisters.PodLister
+	scope  string
+}
+
+// NewCachedPodAPI creates a new CachedPodAPI instance backed by the pod informer of the given factory.
+// The informer is registered with the factory; the caller is responsible for starting it.
+// A factory restricted to one namespace must be declared with options.WithCacheNamespace.
+// It returns an implementation of the api.PodAPI interface.
+func NewCachedPodAPI(client kubernetes.Interface, factory informers.SharedInformerFactory,
+	opts ...options.Option) api.PodAPI {
+
+	return &CachedPodAPI{
+		PodAPI: newPodAPI(client, opts...),
+		lister: factory.Core().V1().Pods().Lister(),
+		scope:  options.Apply(opts...).CacheNamespace,
+	}
+}
+
+// GetPodByName retrieves a specific Pod by namespace and name from the informer cache.
+//
+// Parameters:
+//   - ctx: Context of the API server request for namespaces outside the cache.
+//   - namespace: Namespace of the pod (must be non-empty).
+//   - name: Name of the pod (must be non-empty).
+//
+// Returns a copy of the cached *corev1.Pod or an error if not found or invalid.
+func (c *CachedPodAPI) GetPodByName(ctx context.Context, namespace, name string) (*corev1.Pod, error) {
+	if !cache.Covers(c.scope, namespace) {
+		return c.PodAPI.GetPodByName(ctx, namespace, name)
+	}
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, classify.Invalid(kind, namespace, name, fmt.Errorf("invalid namespace: %w", err))
+	}
+	if err := val.ValidateWithTag(name, "required"); err != nil {
+		return nil, classify.Invalid(kind, namespace, name, fmt.Errorf("invalid pod name: %w", err))
+	}
+
+	pod, err := c.lister.Pods(namespace).Get(name)
+	if err != nil {
+		return nil, classify.Wrap(kind, namespace, name,
+			fmt.Errorf("failed to get pod %q in namespace %q: %w", name, namespace, err))
+	}
+
+	return pod.DeepCopy(), nil
+}
+
+// ListPodsByLabel lists cached pods by namespace and label selector.
+// Namespaces outside the cache are listed from the API server; otherwise the timeout and
+// limit are validated for interface compatibility but not used.
+//
+// Returns all matching pods sorted by name or an error if validation fails.
+func (c *CachedPodAPI) ListPodsByLabel(ctx context.Context, namespace string, labelSelector string,
+	timeoutSeconds time.Duration, limit int64) ([]corev1.Pod, error) {
+
+	if !cache.Covers(c.scope, namespace) {
+		return c.PodAPI.ListPodsByLabel(ctx, namespace, labelSelector, timeoutSeconds, limit)
+	}
+	if err := validateInput(namespace, timeoutSeconds, limit); err != nil {
+		return nil, err
+	}
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, classify.Invalid(kind, namespace, "", fmt.Errorf("invalid label selector: %w", err))
+	}
+
+	parsed, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, classify.Invalid(kind, namespace, "", fmt.Errorf("invalid label selector: %w", err))
+	}
+
+	return c.list(namespace, parsed, fields.Everything())
+}
+
+// ListPodsByField lists cached pods by namespace and field selector.
+// Namespaces outside the cache are listed from the API server; otherwise the timeout and
+// limit are validated for interface compatibility but not used.
+//
+// Returns all matching pods sorted by name or an error if validation fails.
+func (c *CachedPodAPI) ListPodsByField(ctx context.Context, namespace string, fieldSelector string,
+	timeoutSeconds time.Duration, limit int64) ([]corev1.Pod, error) {
+
+	if !cache.Covers(c.scope, namespace) {
+		return c.PodAPI.ListPodsByField(ctx, namespace, fieldSelector, timeoutSeconds, limit)
+	}
+	if err := validateInput(namespace, timeoutSeconds, limit); err != nil {
+		return nil, err
+	}
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, classify.Invalid(kind, namespace, "", fmt.Errorf("invalid field selector: %w", err))
+	}
+
+	parsed, err := cache.Fields(selector.Pods, fieldSelector)
+	if err != nil {
+		return nil, classify.Invalid(kind, namespace, "", fmt.Errorf("invalid field selector: %w", err))
+	}
+
+	return c.list(namespace, labels.Everything(), parsed)
+}
+
+// ListPodsByQuery lists cached pods by namespace and combined query.
+// Queries pinned to a resourceVersion or outside the namespace of the cache are delegated
+// to the API server; otherwise the timeout and limit are validated for interface
+// compatibility but not used.
+//
+// Returns all matching pods sorted by name or an error if validation fails.
+func (c *CachedPodAPI) ListPodsByQuery(ctx context.Context, namespace string,
+	query api.ListQuery) ([]corev1.Pod, error) {
+
+	if !cache.Servable(query) || !cache.Covers(c.scope, namespace) {
+		return c.PodAPI.ListPodsByQuery(ctx, namespace, query)
+	}
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, classify.Invalid(kind, namespace, "", fmt.Errorf("invalid namespace: %w", err))
+	}
+	if err := val.ValidateStruct(query); err != nil {
+		return nil, classify.Invalid(kind, namespace, "", fmt.Errorf("invalid list query: %w", err))
+	}
+
+	labelSelector, fieldSelector, err := cache.Selectors(selector.Pods, query)
+	if err != nil {
+		return nil, classify.Invalid(kind, namespace, "", err)
+	}
+
+	return c.list(namespace, labelSelector, fieldSelector)
+}
+
+// list returns copies of the cached pods in namespace matching both selectors.
+func (c *CachedPodAPI) list(namespace string, labelSelector labels.Selector,
+	fieldSelector fields.Selector) ([]corev1.Pod, error) {
+
+	pods, err := c.lister.Pods(namespace).List(labelSelector)
+	if err != nil {
+		return nil, classify.Wrap(kind, namespace, "",
+			fmt.Errorf("failed to list cached pods in namespace %q: %w", namespace, err))
+	}
+
+	return cache.Items(pods, fieldSelector, podFields), nil
+}
+
+// podFields returns the selectable fields of a pod as evaluated by the API server.
+func podFields(pod *corev1.Pod) fields.Set {
+	set := cache.ObjectMetaFields(pod)
+	set["spec.nodeName"] = pod.Spec.NodeName
+	set["status.phase"] = string(pod.Status.Phase)
+	set["status.hostIP"] = pod.Status.HostIP
+	set["status.podIP"] = pod.Status.PodIP
+
+	return set
+}
@@ -0,0 +1,189 @@
+package pod
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+
+	api "github.com/kaudit/k8s_client"
+	"github.com/kaudit/k8s_client/internal/api/options"
+	"github.com/kaudit/k8s_client/selector"
+)
+
+func newSyncedCachedPodAPI(t *testing.T, objects ...*corev1.Pod) *CachedPodAPI {
+	t.Helper()
+
+	fakeClient := fake.NewClientset()
+	for _, obj := range objects {
+		_, err := fakeClient.CoreV1().Pods(obj.Namespace).Create(context.Background(), obj, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	factory := informers.NewSharedInformerFactory(fakeClient, 0)
+	podAPI := NewCachedPodAPI(fakeClient, factory)
+
+	stopCh := make(chan struct{})
+	t.Cleanup(func() {
+		close(stopCh)
+		factory.Shutdown()
+	})
+	factory.Start(stopCh)
+	for _, synced := range factory.WaitForCacheSync(stopCh) {
+		require.True(t, synced)
+	}
+
+	impl, ok := podAPI.(*CachedPodAPI)
+	require.True(t, ok)
+
+	return impl
+}
+
+func TestCachedPodAPI(t *testing.T) {
+	podAPI := newSyncedCachedPodAPI(t,
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "test-namespace", Labels: map[string]string{"app": "web"}},
+			Status:     corev1.PodStatus{Phase: corev1.PodPending},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "test-namespace", Labels: map[string]string{"app": "web"}},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "db-1", Namespace: "other-namespace", Labels: map[string]string{"app": "db"}},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+	)
+	ctx := context.Background()
+
+	t.Run("Get cached pod", func(t *testing.T) {
+		pod, err := podAPI.GetPodByName(ctx, "test-namespace", "web-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "web-1", pod.Name)
+
+		pod.Labels["app"] = "mutated"
+		again, err := podAPI.GetPodByName(ctx, "test-namespace", "web-1")
+		require.NoError(t, err)
+		assert.Equal(t, "web", again.Labels["app"])
+	})
+
+	t.Run("Get missing pod", func(t *testing.T) {
+		pod, err := podAPI.GetPodByName(ctx, "test-namespace", "missing")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get pod")
+		assert.ErrorIs(t, err, api.ErrNotFound)
+		assert.Nil(t, pod)
+	})
+
+	t.Run("List by label sorted by name", func(t *testing.T) {
+		pods, err := podAPI.ListPodsByLabel(ctx, "test-namespace", "app=web", 2*time.Second, 1)
+
+		require.NoError(t, err)
+		require.Len(t, pods, 2)
+		assert.Equal(t, "web-1", pods[0].Name)
+		assert.Equal(t, "web-2", pods[1].Name)
+	})
+
+	t.Run("List by field", func(t *testing.T) {
+		pods, err := podAPI.ListPodsByField(ctx, "test-namespace", "status.phase=Running", 2*time.Second, 1)
+
+		require.NoError(t, err)
+		require.Len(t, pods, 1)
+		assert.Equal(t, "web-1", pods[0].Name)
+	})
+
+	t.Run("List by query combining selectors", func(t *testing.T) {
+		items, err := podAPI.ListPodsByQuery(ctx, "test-namespace", api.ListQuery{
+			LabelSelector:  "app=web",
+			FieldSelector:  "status.phase=Running",
+			TimeoutSeconds: 2 * time.Second,
+			Limit:          1,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, "web-1", items[0].Name)
+	})
+
+	t.Run("Invalid input", func(t *testing.T) {
+		_, err := podAPI.ListPodsByLabel(ctx, "", "app=web", 2*time.Second, 1)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid namespace")
+
+		_, err = podAPI.ListPodsByField(ctx, "test-namespace", "spec.unknown=x", 2*time.Second, 1)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid field selector")
+	})
+
+	t.Run("Unsupported field", func(t *testing.T) {
+		for _, fieldSelector := range []string{"spec.type=x", "spec.type!=x"} {
+			_, err := podAPI.ListPodsByField(ctx, "test-namespace", fieldSelector, 2*time.Second, 1)
+			require.ErrorIs(t, err, api.ErrInvalidInput)
+			require.ErrorIs(t, err, selector.ErrUnsupportedField)
+
+			_, err = podAPI.ListPodsByQuery(ctx, "test-namespace", api.ListQuery{
+				FieldSelector:  fieldSelector,
+				TimeoutSeconds: 2 * time.Second,
+				Limit:          1,
+			})
+			require.ErrorIs(t, err, api.ErrInvalidInput)
+			require.ErrorIs(t, err, selector.ErrUnsupportedField)
+		}
+	})
+}
+
+func TestCachedPodAPI_CacheNamespace(t *testing.T) {
+	fakeClient := fake.NewClientset(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "team-a", Labels: map[string]string{"app": "web"}}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "team-b", Labels: map[string]string{"app": "web"}}},
+	)
+
+	factory := informers.NewSharedInformerFactoryWithOptions(fakeClient, 0, informers.WithNamespace("team-a"))
+	podAPI := NewCachedPodAPI(fakeClient, factory, options.WithCacheNamespace("team-a"))
+
+	stopCh := make(chan struct{})
+	defer func() {
+		close(stopCh)
+		factory.Shutdown()
+	}()
+	factory.Start(stopCh)
+	for _, synced := range factory.WaitForCacheSync(stopCh) {
+		require.True(t, synced)
+	}
+
+	ctx := context.Background()
+
+	t.Run("Serves namespace of the cache", func(t *testing.T) {
+		pods, err := podAPI.ListPodsByLabel(ctx, "team-a", "app=web", 2*time.Second, 1)
+
+		require.NoError(t, err)
+		require.Len(t, pods, 1)
+		assert.Equal(t, "web-1", pods[0].Name)
+	})
+
+	t.Run("Delegates other namespaces to the API server", func(t *testing.T) {
+		pod, err := podAPI.GetPodByName(ctx, "team-b", "web-2")
+		require.NoError(t, err)
+		assert.Equal(t, "web-2", pod.Name)
+
+		pods, err := podAPI.ListPodsByField(ctx, "team-b", "metadata.name=web-2", 2*time.Second, 10)
+		require.NoError(t, err)
+		require.Len(t, pods, 1)
+
+		pods, err = podAPI.ListPodsByQuery(ctx, "team-b", api.ListQuery{
+			LabelSelector:  "app=web",
+			TimeoutSeconds: 2 * time.Second,
+			Limit:          10,
+		})
+		require.NoError(t, err)
+		require.Len(t, pods, 1)
+	})
+}
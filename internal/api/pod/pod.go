@@ -5,27 +5,52 @@ package pod
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/kaudit/val"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/metadata"
 
 	api "github.com/kaudit/k8s_client"
+	"github.com/kaudit/k8s_client/internal/api/classify"
+	"github.com/kaudit/k8s_client/internal/api/logging"
+	"github.com/kaudit/k8s_client/internal/api/options"
+	"github.com/kaudit/k8s_client/internal/api/pager"
+	"github.com/kaudit/k8s_client/internal/api/retry"
+	"github.com/kaudit/k8s_client/internal/api/watcher"
 )
 
+// kind is the resource kind reported in returned *api.Error values.
+const kind = "Pod"
+
 // PodAPI provides high-level methods for retrieving Kubernetes pods.
 // It handles input validation and supports pagination for list operations.
 type PodAPI struct {
-	client kubernetes.Interface
+	client   kubernetes.Interface
+	metadata metadata.Interface
+	logger   *slog.Logger
+	retry    api.RetryPolicy
 }
 
 // NewPodAPI creates a new PodAPI instance using the provided Kubernetes client.
+// Optional settings such as the metadata client are supplied through opts.
 // It returns an implementation of the api.PodAPI interface.
-func NewPodAPI(client kubernetes.Interface) api.PodAPI {
+func NewPodAPI(client kubernetes.Interface, opts ...options.Option) api.PodAPI {
+	return newPodAPI(client, opts...)
+}
+
+// newPodAPI creates a PodAPI from the client and the resolved options.
+func newPodAPI(client kubernetes.Interface, opts ...options.Option) *PodAPI {
+	o := options.Apply(opts...)
+
 	return &PodAPI{
-		client: client,
+		client:   client,
+		metadata: o.Metadata,
+		logger:   o.Logger,
+		retry:    o.Retry,
 	}
 }
 
@@ -39,15 +64,24 @@ func NewPodAPI(client kubernetes.Interface) api.PodAPI {
 // Returns the matched *corev1.Pod or an error if not found or invalid.
 func (p *PodAPI) GetPodByName(ctx context.Context, namespace, name string) (*corev1.Pod, error) {
 	if err := val.ValidateWithTag(namespace, "required"); err != nil {
-		return nil, fmt.Errorf("invalid namespace: %w", err)
+		return nil, classify.Invalid(kind, namespace, name, fmt.Errorf("invalid namespace: %w", err))
 	}
 	if err := val.ValidateWithTag(name, "required"); err != nil {
-		return nil, fmt.Errorf("invalid pod name: %w", err)
+		return nil, classify.Invalid(kind, namespace, name, fmt.Errorf("invalid pod name: %w", err))
 	}
 
-	pod, err := p.client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	call := logging.Start(p.logger, "get", kind, namespace, slog.String("name", name))
+
+	var pod *corev1.Pod
+	err := retry.Do(ctx, p.retry, func() error {
+		var err error
+		pod, err = p.client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		return err
+	})
+	call.Fetched(ctx, err)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get pod %q in namespace %q: %w", name, namespace, err)
+		return nil, classify.Wrap(kind, namespace, name,
+			fmt.Errorf("failed to get pod %q in namespace %q: %w", name, namespace, err))
 	}
 
 	return pod, nil
@@ -70,7 +104,7 @@ func (p *PodAPI) ListPodsByLabel(ctx context.Context, namespace string, labelSel
 		return nil, err
 	}
 	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
-		return nil, fmt.Errorf("invalid label selector: %w", err)
+		return nil, classify.Invalid(kind, namespace, "", fmt.Errorf("invalid label selector: %w", err))
 	}
 
 	seconds := int64(timeoutSeconds.Seconds())
@@ -101,7 +135,7 @@ func (p *PodAPI) ListPodsByField(ctx context.Context, namespace string, fieldSel
 		return nil, err
 	}
 	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
-		return nil, fmt.Errorf("invalid field selector: %w", err)
+		return nil, classify.Invalid(kind, namespace, "", fmt.Errorf("invalid field selector: %w", err))
 	}
 
 	seconds := int64(timeoutSeconds.Seconds())
@@ -115,20 +149,158 @@ func (p *PodAPI) ListPodsByField(ctx context.Context, namespace string, fieldSel
 	return p.loopForResult(ctx, namespace, opts)
 }
 
+// ListPodsByQuery lists pods by namespace using a combined query with pagination support.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control.
+//   - namespace: Namespace scope for the query (must be non-empty).
+//   - query: Query with optional label and field selectors applied together, optional
+//     resourceVersion constraints, timeout and page limit.
+//
+// Returns all matching pods across all pages or an error if validation fails or API calls fail.
+func (p *PodAPI) ListPodsByQuery(ctx context.Context, namespace string,
+	query api.ListQuery) ([]corev1.Pod, error) {
+
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, classify.Invalid(kind, namespace, "", fmt.Errorf("invalid namespace: %w", err))
+	}
+	if err := val.ValidateStruct(query); err != nil {
+		return nil, classify.Invalid(kind, namespace, "", fmt.Errorf("invalid list query: %w", err))
+	}
+
+	return p.loopForResult(ctx, namespace, query.ListOptions())
+}
+
+// ListPodsSnapshot lists pods by namespace at a single resourceVersion with pagination support.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control.
+//   - namespace: Namespace scope for the query (must be non-empty).
+//   - opts: Snapshot options with optional label and field selectors, timeout, page limit
+//     and the policy applied when a continue token expires.
+//
+// Returns a snapshot with all matching pods and the resourceVersion of the first page.
+// With api.ExpiredContinuePartial, an expired continue token yields the partial snapshot
+// together with an error wrapping *api.ContinueExpiredError.
+func (p *PodAPI) ListPodsSnapshot(ctx context.Context, namespace string,
+	opts api.SnapshotOptions) (*api.Snapshot[corev1.Pod], error) {
+
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, classify.Invalid(kind, namespace, "", fmt.Errorf("invalid namespace: %w", err))
+	}
+	if err := val.ValidateStruct(opts); err != nil {
+		return nil, classify.Invalid(kind, namespace, "", fmt.Errorf("invalid snapshot options: %w", err))
+	}
+
+	call := logging.Start(p.logger, "snapshot", kind, namespace,
+		logging.Selectors(opts.LabelSelector, opts.FieldSelector, opts.Limit)...)
+
+	snapshot, err := pager.Snapshot(ctx, opts, pager.Logged(call, p.listPage(namespace)))
+	logging.Snapshot(ctx, call, snapshot, err)
+	if err != nil {
+		return snapshot, classify.Wrap(kind, namespace, "",
+			fmt.Errorf("failed to list pods snapshot in namespace %q: %w", namespace, err))
+	}
+
+	return snapshot, nil
+}
+
+// WatchPods watches pods by namespace and delivers typed change events.
+//
+// Parameters:
+//   - ctx: Context controlling the watch; the returned channel is closed when it is done.
+//   - namespace: Namespace scope for the watch (must be non-empty).
+//   - opts: Watch options with optional label and field selectors and a starting resourceVersion.
+//
+// The watch follows bookmarks and resumes from the last observed resourceVersion after a
+// disconnect. Returns the event channel or an error if validation or the initial watch call fails.
+func (p *PodAPI) WatchPods(ctx context.Context, namespace string,
+	opts api.WatchOptions) (<-chan api.WatchEvent[*corev1.Pod], error) {
+
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, classify.Invalid(kind, namespace, "", fmt.Errorf("invalid namespace: %w", err))
+	}
+	if err := val.ValidateStruct(opts); err != nil {
+		return nil, classify.Invalid(kind, namespace, "", fmt.Errorf("invalid watch options: %w", err))
+	}
+
+	call := logging.Start(p.logger, "watch", kind, namespace,
+		logging.Selectors(opts.LabelSelector, opts.FieldSelector, 0)...)
+
+	events, err := watcher.Watch[*corev1.Pod](ctx, opts, p.client.CoreV1().Pods(namespace).Watch)
+	call.End(ctx, err)
+	if err != nil {
+		return nil, classify.Wrap(kind, namespace, "",
+			fmt.Errorf("failed to watch pods in namespace %q: %w", namespace, err))
+	}
+
+	return events, nil
+}
+
+// ListPodsMetadata lists the metadata of pods by namespace with pagination support.
+// Only object metadata (names, labels, annotations, owners, timestamps) is transferred,
+// which keeps responses small for inventory purposes.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control.
+//   - namespace: Namespace scope for the query (must be non-empty).
+//   - labelSelector: Optional Kubernetes label selector; when empty, all pods are listed.
+//   - timeoutSeconds: Timeout duration for the API call (must be at least 1s).
+//   - limit: Maximum number of results per page (must be greater than 0).
+//
+// Returns the metadata of all matching pods across all pages or an error if validation fails,
+// no metadata client is configured, or API calls fail.
+func (p *PodAPI) ListPodsMetadata(ctx context.Context, namespace string, labelSelector string,
+	timeoutSeconds time.Duration, limit int64) ([]metav1.PartialObjectMetadata, error) {
+
+	if err := validateInput(namespace, timeoutSeconds, limit); err != nil {
+		return nil, err
+	}
+	if err := val.ValidateWithTag(labelSelector, "omitempty,k8s_label_selector"); err != nil {
+		return nil, classify.Invalid(kind, namespace, "", fmt.Errorf("invalid label selector: %w", err))
+	}
+	if p.metadata == nil {
+		return nil, classify.Wrap(kind, namespace, "", options.ErrNoMetadataClient)
+	}
+
+	seconds := int64(timeoutSeconds.Seconds())
+
+	opts := metav1.ListOptions{
+		LabelSelector:  labelSelector,
+		Limit:          limit,
+		TimeoutSeconds: &seconds,
+	}
+
+	resource := p.metadata.Resource(corev1.SchemeGroupVersion.WithResource("pods")).Namespace(namespace)
+
+	call := logging.Start(p.logger, "list_metadata", kind, namespace,
+		logging.Selectors(labelSelector, "", limit)...)
+	fetch := pager.Retrying(p.retry, pager.MetadataPage(resource))
+
+	result, err := pager.Collect(ctx, opts, pager.Logged(call, fetch))
+	call.Done(ctx, len(result), err)
+	if err != nil {
+		return nil, classify.Wrap(kind, namespace, "",
+			fmt.Errorf("failed to list pods metadata in namespace %q: %w", namespace, err))
+	}
+
+	return result, nil
+}
+
 // validateInput validates common input parameters for list operations.
 // It checks that namespace is non-empty, timeout is at least 1 second, and limit is positive.
 // Returns an error with detailed information if validation fails.
 func validateInput(namespace string, timeoutSeconds time.Duration, limit int64) error {
 	if err := val.ValidateWithTag(namespace, "required"); err != nil {
-		return fmt.Errorf("invalid namespace: %w", err)
+		return classify.Invalid(kind, namespace, "", fmt.Errorf("invalid namespace: %w", err))
 	}
 
 	if err := val.ValidateWithTag(timeoutSeconds, "required,min=1s"); err != nil {
-		return fmt.Errorf("invalid timeout: %w", err)
+		return classify.Invalid(kind, namespace, "", fmt.Errorf("invalid timeout: %w", err))
 	}
 
 	if err := val.ValidateWithTag(limit, "required,gt=0"); err != nil {
-		return fmt.Errorf("invalid limit: %w", err)
+		return classify.Invalid(kind, namespace, "", fmt.Errorf("invalid limit: %w", err))
 	}
 
 	return nil
@@ -146,22 +318,34 @@ func validateInput(namespace string, timeoutSeconds time.Duration, limit int64)
 func (p *PodAPI) loopForResult(ctx context.Context, namespace string,
 	opts metav1.ListOptions) ([]corev1.Pod, error) {
 
-	var result []corev1.Pod
+	call := logging.Start(p.logger, "list", kind, namespace,
+		logging.Selectors(opts.LabelSelector, opts.FieldSelector, opts.Limit)...)
 
-	for {
-		list, err := p.client.CoreV1().Pods(namespace).List(ctx, opts)
-		if err != nil {
-			return nil, fmt.Errorf("failed to list pods in namespace %q: %w", namespace, err)
-		}
+	result, err := pager.Collect(ctx, opts, pager.Logged(call, p.listPage(namespace)))
+	call.Done(ctx, len(result), err)
+	if err != nil {
+		return nil, classify.Wrap(kind, namespace, "",
+			fmt.Errorf("failed to list pods in namespace %q: %w", namespace, err))
+	}
 
-		result = append(result, list.Items...)
+	return result, nil
+}
 
-		if list.Continue == "" {
-			break
+// listPage returns a pager.PageFunc fetching a single page of pods in the given namespace.
+// Transient failures of each page are retried under the configured retry policy.
+func (p *PodAPI) listPage(namespace string) pager.PageFunc[corev1.Pod] {
+	fetch := func(ctx context.Context, opts metav1.ListOptions) (pager.Page[corev1.Pod], error) {
+		list, err := p.client.CoreV1().Pods(namespace).List(ctx, opts)
+		if err != nil {
+			return pager.Page[corev1.Pod]{}, err
 		}
 
-		opts.Continue = list.Continue
+		return pager.Page[corev1.Pod]{
+			Items:           list.Items,
+			Continue:        list.Continue,
+			ResourceVersion: list.ResourceVersion,
+		}, nil
 	}
 
-	return result, nil
+	return pager.Retrying(p.retry, fetch)
 }
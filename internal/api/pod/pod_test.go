@@ -1,15 +1,27 @@
 package pod
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"log/slog"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes/fake"
+	metadatafake "k8s.io/client-go/metadata/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	api "github.com/kaudit/k8s_client"
+	"github.com/kaudit/k8s_client/internal/api/options"
 )
 
 func TestPodAPI_New(t *testing.T) {
@@ -124,6 +136,7 @@ func TestPodAPI_GetPodByName(t *testing.T) {
 		podName       string
 		wantErr       bool
 		errorContains string
+		wantErrIs     error
 	}{
 		{
 			name:      "Successfully get pod",
@@ -137,6 +150,7 @@ func TestPodAPI_GetPodByName(t *testing.T) {
 			podName:       "test-pod",
 			wantErr:       true,
 			errorContains: "invalid namespace",
+			wantErrIs:     api.ErrInvalidInput,
 		},
 		{
 			name:          "Empty pod name",
@@ -144,6 +158,7 @@ func TestPodAPI_GetPodByName(t *testing.T) {
 			podName:       "",
 			wantErr:       true,
 			errorContains: "invalid pod name",
+			wantErrIs:     api.ErrInvalidInput,
 		},
 		{
 			name:          "Pod not found",
@@ -151,6 +166,7 @@ func TestPodAPI_GetPodByName(t *testing.T) {
 			podName:       "nonexistent-pod",
 			wantErr:       true,
 			errorContains: "failed to get pod",
+			wantErrIs:     api.ErrNotFound,
 		},
 	}
 
@@ -164,6 +180,10 @@ func TestPodAPI_GetPodByName(t *testing.T) {
 				if tt.errorContains != "" {
 					assert.Contains(t, err.Error(), tt.errorContains)
 				}
+				require.ErrorIs(t, err, tt.wantErrIs)
+				var apiErr *api.Error
+				require.ErrorAs(t, err, &apiErr)
+				assert.Equal(t, "Pod", apiErr.Kind)
 				assert.Nil(t, pod)
 			} else {
 				require.NoError(t, err)
@@ -518,3 +538,464 @@ func TestPodAPI_ListPodsByField(t *testing.T) {
 		})
 	}
 }
+
+func TestPodAPI_ListPodsByQuery(t *testing.T) {
+	// Serve two pages and record the options of every request
+	fakeClient := fake.NewClientset()
+	var requests []metav1.ListOptions
+	fakeClient.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		opts := action.(k8stesting.ListActionImpl).GetListOptions()
+		requests = append(requests, opts)
+		if opts.Continue == "" {
+			return true, &corev1.PodList{
+				ListMeta: metav1.ListMeta{ResourceVersion: "10", Continue: "token"},
+				Items:    []corev1.Pod{{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "test-namespace", Labels: map[string]string{"app": "web"}}}},
+			}, nil
+		}
+		return true, &corev1.PodList{
+			ListMeta: metav1.ListMeta{ResourceVersion: "10"},
+			Items:    []corev1.Pod{{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "test-namespace", Labels: map[string]string{"app": "web"}}}},
+		}, nil
+	})
+
+	podAPI := NewPodAPI(fakeClient)
+
+	tests := []struct {
+		name          string
+		namespace     string
+		query         api.ListQuery
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:      "Combined selectors with resourceVersion",
+			namespace: "test-namespace",
+			query: api.ListQuery{
+				LabelSelector:        "app=web",
+				FieldSelector:        "status.phase=Running",
+				ResourceVersion:      "5",
+				ResourceVersionMatch: metav1.ResourceVersionMatchNotOlderThan,
+				TimeoutSeconds:       2 * time.Second,
+				Limit:                1,
+			},
+			wantErr: false,
+		},
+		{
+			name:          "Empty namespace",
+			namespace:     "",
+			query:         api.ListQuery{TimeoutSeconds: 2 * time.Second, Limit: 1},
+			wantErr:       true,
+			errorContains: "invalid namespace",
+		},
+		{
+			name:          "Invalid label selector",
+			namespace:     "test-namespace",
+			query:         api.ListQuery{LabelSelector: "app in (web", TimeoutSeconds: 2 * time.Second, Limit: 1},
+			wantErr:       true,
+			errorContains: "invalid list query",
+		},
+		{
+			name:          "Invalid field selector",
+			namespace:     "test-namespace",
+			query:         api.ListQuery{FieldSelector: "spec.unknown=x", TimeoutSeconds: 2 * time.Second, Limit: 1},
+			wantErr:       true,
+			errorContains: "invalid list query",
+		},
+		{
+			name:      "Match without resourceVersion",
+			namespace: "test-namespace",
+			query: api.ListQuery{
+				ResourceVersionMatch: metav1.ResourceVersionMatchExact,
+				TimeoutSeconds:       2 * time.Second,
+				Limit:                1,
+			},
+			wantErr:       true,
+			errorContains: "invalid list query",
+		},
+		{
+			name:          "Invalid limit",
+			namespace:     "test-namespace",
+			query:         api.ListQuery{TimeoutSeconds: 2 * time.Second},
+			wantErr:       true,
+			errorContains: "invalid list query",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests = nil
+			ctx := context.Background()
+			items, err := podAPI.ListPodsByQuery(ctx, tt.namespace, tt.query)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, items)
+				assert.Empty(t, requests)
+				return
+			}
+
+			require.NoError(t, err)
+			names := make([]string, 0, len(items))
+			for _, item := range items {
+				names = append(names, item.Name)
+			}
+			assert.Equal(t, []string{"a", "b"}, names)
+			require.Len(t, requests, 2)
+			assert.Equal(t, tt.query.LabelSelector, requests[0].LabelSelector)
+			assert.Equal(t, tt.query.FieldSelector, requests[0].FieldSelector)
+			assert.Equal(t, "5", requests[0].ResourceVersion)
+			assert.Equal(t, metav1.ResourceVersionMatchNotOlderThan, requests[0].ResourceVersionMatch)
+			assert.Equal(t, "token", requests[1].Continue)
+			assert.Empty(t, requests[1].ResourceVersion)
+			assert.Empty(t, requests[1].ResourceVersionMatch)
+		})
+	}
+}
+
+func TestPodAPI_ListPodsSnapshot(t *testing.T) {
+	// Serve a first page, expire its continue token once and then serve the full list
+	fakeClient := fake.NewClientset()
+	calls := 0
+	fakeClient.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		calls++
+		opts := action.(k8stesting.ListActionImpl).GetListOptions()
+		switch {
+		case calls == 1:
+			return true, &corev1.PodList{
+				ListMeta: metav1.ListMeta{ResourceVersion: "10", Continue: "token"},
+				Items:    []corev1.Pod{{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "test-namespace"}}},
+			}, nil
+		case opts.Continue != "":
+			return true, nil, apierrors.NewResourceExpired("continue token too old")
+		default:
+			return true, &corev1.PodList{
+				ListMeta: metav1.ListMeta{ResourceVersion: "20"},
+				Items: []corev1.Pod{
+					{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "test-namespace"}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "test-namespace"}},
+				},
+			}, nil
+		}
+	})
+
+	podAPI := NewPodAPI(fakeClient)
+
+	tests := []struct {
+		name          string
+		namespace     string
+		opts          api.SnapshotOptions
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:      "Restarts after expired continue token",
+			namespace: "test-namespace",
+			opts:      api.SnapshotOptions{TimeoutSeconds: 2 * time.Second, Limit: 1},
+			wantErr:   false,
+		},
+		{
+			name:          "Empty namespace",
+			namespace:     "",
+			opts:          api.SnapshotOptions{TimeoutSeconds: 2 * time.Second, Limit: 1},
+			wantErr:       true,
+			errorContains: "invalid namespace",
+		},
+		{
+			name:          "Invalid label selector",
+			namespace:     "test-namespace",
+			opts:          api.SnapshotOptions{LabelSelector: "app in (web", TimeoutSeconds: 2 * time.Second, Limit: 1},
+			wantErr:       true,
+			errorContains: "invalid snapshot options",
+		},
+		{
+			name:          "Invalid limit",
+			namespace:     "test-namespace",
+			opts:          api.SnapshotOptions{TimeoutSeconds: 2 * time.Second},
+			wantErr:       true,
+			errorContains: "invalid snapshot options",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			snapshot, err := podAPI.ListPodsSnapshot(ctx, tt.namespace, tt.opts)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, snapshot)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "20", snapshot.ResourceVersion)
+			assert.Equal(t, 1, snapshot.Restarts)
+			assert.True(t, snapshot.Complete)
+			names := make([]string, 0, len(snapshot.Items))
+			for _, item := range snapshot.Items {
+				names = append(names, item.Name)
+			}
+			assert.Equal(t, []string{"a", "b"}, names)
+		})
+	}
+}
+
+func TestPodAPI_WatchPods(t *testing.T) {
+	fakeClient := fake.NewClientset()
+	podAPI := NewPodAPI(fakeClient)
+
+	t.Run("Delivers added event", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events, err := podAPI.WatchPods(ctx, "test-namespace", api.WatchOptions{})
+		require.NoError(t, err)
+
+		_, err = fakeClient.CoreV1().Pods("test-namespace").Create(ctx,
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "watched", Namespace: "test-namespace"}}, metav1.CreateOptions{})
+		require.NoError(t, err)
+
+		select {
+		case event := <-events:
+			assert.Equal(t, api.EventAdded, event.Type)
+			assert.Equal(t, "watched", event.Object.Name)
+		case <-time.After(5 * time.Second):
+			t.Fatal("no event received")
+		}
+	})
+
+	t.Run("Empty namespace", func(t *testing.T) {
+		events, err := podAPI.WatchPods(context.Background(), "", api.WatchOptions{})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid namespace")
+		assert.Nil(t, events)
+	})
+
+	t.Run("Invalid label selector", func(t *testing.T) {
+		events, err := podAPI.WatchPods(context.Background(), "test-namespace", api.WatchOptions{LabelSelector: "app in (web"})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid watch options")
+		assert.Nil(t, events)
+	})
+}
+
+func TestPodAPI_ListPodsMetadata(t *testing.T) {
+	scheme := metadatafake.NewTestScheme()
+	require.NoError(t, metav1.AddMetaToScheme(scheme))
+
+	newMetadata := func(name string, labels map[string]string) *metav1.PartialObjectMetadata {
+		return &metav1.PartialObjectMetadata{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: name, Labels: labels},
+		}
+	}
+	metadataClient := metadatafake.NewSimpleMetadataClient(scheme,
+		newMetadata("web", map[string]string{"app": "web"}),
+		newMetadata("db", map[string]string{"app": "db"}),
+	)
+
+	tests := []struct {
+		name           string
+		api            *PodAPI
+		namespace      string
+		labelSelector  string
+		timeoutSeconds time.Duration
+		limit          int64
+		expectedNames  []string
+		wantErr        bool
+		errorContains  string
+	}{
+		{
+			name:           "List all metadata",
+			api:            newPodAPI(fake.NewClientset(), options.WithMetadataClient(metadataClient)),
+			namespace:      "test-namespace",
+			timeoutSeconds: 2 * time.Second,
+			limit:          1,
+			expectedNames:  []string{"db", "web"},
+		},
+		{
+			name:           "List metadata by label",
+			api:            newPodAPI(fake.NewClientset(), options.WithMetadataClient(metadataClient)),
+			namespace:      "test-namespace",
+			labelSelector:  "app=web",
+			timeoutSeconds: 2 * time.Second,
+			limit:          1,
+			expectedNames:  []string{"web"},
+		},
+		{
+			name:           "Empty namespace",
+			api:            newPodAPI(fake.NewClientset(), options.WithMetadataClient(metadataClient)),
+			namespace:      "",
+			timeoutSeconds: 2 * time.Second,
+			limit:          1,
+			wantErr:        true,
+			errorContains:  "invalid namespace",
+		},
+		{
+			name:           "Invalid label selector",
+			api:            newPodAPI(fake.NewClientset(), options.WithMetadataClient(metadataClient)),
+			namespace:      "test-namespace",
+			labelSelector:  "app in (web",
+			timeoutSeconds: 2 * time.Second,
+			limit:          1,
+			wantErr:        true,
+			errorContains:  "invalid label selector",
+		},
+		{
+			name:           "Missing metadata client",
+			api:            newPodAPI(fake.NewClientset()),
+			namespace:      "test-namespace",
+			timeoutSeconds: 2 * time.Second,
+			limit:          1,
+			wantErr:        true,
+			errorContains:  options.ErrNoMetadataClient.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := tt.api.ListPodsMetadata(context.Background(), tt.namespace, tt.labelSelector,
+				tt.timeoutSeconds, tt.limit)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, items)
+				return
+			}
+
+			require.NoError(t, err)
+			names := make([]string, 0, len(items))
+			for _, item := range items {
+				names = append(names, item.Name)
+			}
+			assert.ElementsMatch(t, tt.expectedNames, names)
+		})
+	}
+}
+
+func TestPodAPI_RetryPolicy(t *testing.T) {
+	// Fail the first calls of every verb and count all attempts
+	fakeClient := fake.NewClientset(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "test-namespace", Labels: map[string]string{"app": "web"}}})
+	var getFailures, listFailures, getCalls int
+	var getErr error
+	fakeClient.PrependReactor("get", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+		getCalls++
+		if getFailures > 0 {
+			getFailures--
+			return true, nil, getErr
+		}
+		return false, nil, nil
+	})
+	fakeClient.PrependReactor("list", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+		if listFailures > 0 {
+			listFailures--
+			return true, nil, apierrors.NewServiceUnavailable("restarting")
+		}
+		return false, nil, nil
+	})
+
+	policy := api.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	podAPI := NewPodAPI(fakeClient, options.WithRetryPolicy(policy))
+	ctx := context.Background()
+
+	t.Run("Retries transient failures", func(t *testing.T) {
+		getFailures, listFailures, getCalls = 2, 2, 0
+		getErr = apierrors.NewTooManyRequests("slow down", 0)
+
+		got, err := podAPI.GetPodByName(ctx, "test-namespace", "web")
+		require.NoError(t, err)
+		assert.Equal(t, "web", got.Name)
+		assert.Equal(t, 3, getCalls)
+
+		items, err := podAPI.ListPodsByLabel(ctx, "test-namespace", "app=web", 2*time.Second, 1)
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+	})
+
+	t.Run("Gives up after max attempts", func(t *testing.T) {
+		listFailures = 3
+
+		_, err := podAPI.ListPodsByLabel(ctx, "test-namespace", "app=web", 2*time.Second, 1)
+		require.Error(t, err)
+		assert.True(t, apierrors.IsServiceUnavailable(err))
+	})
+
+	t.Run("Does not retry permanent errors", func(t *testing.T) {
+		getFailures, getCalls = 3, 0
+		getErr = apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "web", errors.New("denied"))
+
+		_, err := podAPI.GetPodByName(ctx, "test-namespace", "web")
+		require.ErrorIs(t, err, api.ErrForbidden)
+		assert.Equal(t, 1, getCalls)
+	})
+
+	t.Run("Attempts once without policy", func(t *testing.T) {
+		getFailures, getCalls = 1, 0
+		getErr = apierrors.NewTooManyRequests("slow down", 0)
+
+		_, err := NewPodAPI(fakeClient).GetPodByName(ctx, "test-namespace", "web")
+		require.Error(t, err)
+		assert.True(t, apierrors.IsTooManyRequests(err))
+		assert.Equal(t, 1, getCalls)
+	})
+}
+
+func TestPodAPI_Logger(t *testing.T) {
+	// Serve two pages and log at debug level
+	fakeClient := fake.NewClientset()
+	fakeClient.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.(k8stesting.ListActionImpl).GetListOptions().Continue == "" {
+			return true, &corev1.PodList{
+				ListMeta: metav1.ListMeta{ResourceVersion: "10", Continue: "secret-continue"},
+				Items:    []corev1.Pod{{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "test-namespace", Labels: map[string]string{"app": "web"}}}},
+			}, nil
+		}
+		return true, &corev1.PodList{
+			ListMeta: metav1.ListMeta{ResourceVersion: "10"},
+			Items:    []corev1.Pod{{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "test-namespace", Labels: map[string]string{"app": "web"}}}},
+		}, nil
+	})
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	podAPI := NewPodAPI(fakeClient, options.WithLogger(logger))
+	ctx := context.Background()
+
+	t.Run("Logs list pages and summary", func(t *testing.T) {
+		buf.Reset()
+
+		items, err := podAPI.ListPodsByLabel(ctx, "test-namespace", "app=web", 2*time.Second, 1)
+		require.NoError(t, err)
+		require.Len(t, items, 2)
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 3)
+		assert.Contains(t, lines[0], `op=list kind=Pod namespace=test-namespace label_selector="app=web" limit=1 page=1`)
+		assert.Contains(t, lines[2], `msg="kubernetes request"`)
+		assert.Contains(t, lines[2], "pages=2 items=2")
+		assert.NotContains(t, buf.String(), "secret-continue")
+	})
+
+	t.Run("Logs failed get with error class", func(t *testing.T) {
+		buf.Reset()
+
+		_, err := podAPI.GetPodByName(ctx, "test-namespace", "missing")
+		require.ErrorIs(t, err, api.ErrNotFound)
+
+		assert.Contains(t, buf.String(), "op=get kind=Pod namespace=test-namespace name=missing error_class=not_found items=0")
+	})
+
+	t.Run("Logs nothing for invalid input", func(t *testing.T) {
+		buf.Reset()
+
+		_, err := podAPI.GetPodByName(ctx, "", "web")
+		require.ErrorIs(t, err, api.ErrInvalidInput)
+
+		assert.Empty(t, buf.String())
+	})
+}
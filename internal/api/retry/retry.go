@@ -0,0 +1,75 @@
+// Package retry runs API calls under an api.RetryPolicy.
+// Only transient failures are retried; everything else is returned after the first attempt.
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	api "github.com/kaudit/k8s_client"
+)
+
+// Do calls fn until it succeeds, fails with a permanent error, or the attempts of policy
+// are used up. A zero policy calls fn exactly once.
+//
+// Returns nil on success, the last error of fn otherwise, or an error wrapping both the
+// context error and the last error of fn when ctx is done while waiting.
+func Do(ctx context.Context, policy api.RetryPolicy, fn func() error) error {
+	var err error
+
+	for attempt := 1; ; attempt++ {
+		err = fn()
+		if err == nil || attempt >= policy.MaxAttempts || !Retryable(err) {
+			return err
+		}
+
+		timer := time.NewTimer(delay(policy, attempt, err))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("retry interrupted after %d attempts: %w: %w", attempt, ctx.Err(), err)
+		case <-timer.C:
+		}
+	}
+}
+
+// Retryable reports whether err is a transient failure worth retrying.
+func Retryable(err error) bool {
+	switch {
+	case apierrors.IsTooManyRequests(err), apierrors.IsServiceUnavailable(err):
+		return true
+	case apierrors.IsTimeout(err), apierrors.IsServerTimeout(err):
+		return true
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return false
+	default:
+		return utilnet.IsConnectionReset(err) || utilnet.IsProbableEOF(err)
+	}
+}
+
+// delay returns the wait before the attempt following attempt.
+func delay(policy api.RetryPolicy, attempt int, err error) time.Duration {
+	backoff := policy.InitialBackoff
+	for i := 1; i < attempt && backoff < policy.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, policy.MaxBackoff)
+
+	if policy.Jitter > 0 {
+		backoff = wait.Jitter(backoff, policy.Jitter)
+	}
+
+	if seconds, ok := apierrors.SuggestsClientDelay(err); ok {
+		if suggested := time.Duration(seconds) * time.Second; suggested > backoff {
+			return suggested
+		}
+	}
+
+	return backoff
+}
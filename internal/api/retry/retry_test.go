@@ -0,0 +1,147 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	api "github.com/kaudit/k8s_client"
+)
+
+func testPolicy() api.RetryPolicy {
+	return api.RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     4 * time.Millisecond,
+		Jitter:         0.5,
+	}
+}
+
+func TestDo(t *testing.T) {
+	resource := schema.GroupResource{Resource: "pods"}
+
+	tests := []struct {
+		name      string
+		policy    api.RetryPolicy
+		errs      []error
+		wantCalls int
+		wantErr   bool
+	}{
+		{
+			name:      "Succeeds after transient failures",
+			policy:    testPolicy(),
+			errs:      []error{apierrors.NewTooManyRequests("slow down", 0), apierrors.NewServiceUnavailable("restarting")},
+			wantCalls: 3,
+		},
+		{
+			name:      "Gives up after max attempts",
+			policy:    testPolicy(),
+			errs:      []error{syscall.ECONNRESET, syscall.ECONNRESET, syscall.ECONNRESET, nil},
+			wantCalls: 3,
+			wantErr:   true,
+		},
+		{
+			name:      "Does not retry permanent errors",
+			policy:    testPolicy(),
+			errs:      []error{apierrors.NewForbidden(resource, "web", errors.New("denied"))},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name:      "Zero policy attempts once",
+			policy:    api.RetryPolicy{},
+			errs:      []error{apierrors.NewServiceUnavailable("restarting")},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			err := Do(context.Background(), tt.policy, func() error {
+				calls++
+				if calls <= len(tt.errs) {
+					return tt.errs[calls-1]
+				}
+				return nil
+			})
+
+			assert.Equal(t, tt.wantCalls, calls)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Equal(t, tt.errs[calls-1], err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("Stops waiting when context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		policy := api.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Hour, MaxBackoff: time.Hour}
+		unavailable := apierrors.NewServiceUnavailable("restarting")
+
+		err := Do(ctx, policy, func() error {
+			cancel()
+			return unavailable
+		})
+
+		require.ErrorIs(t, err, context.Canceled)
+		assert.ErrorIs(t, err, unavailable)
+	})
+}
+
+func TestRetryable(t *testing.T) {
+	resource := schema.GroupResource{Resource: "pods"}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "Too many requests", err: apierrors.NewTooManyRequests("slow down", 1), want: true},
+		{name: "Service unavailable", err: apierrors.NewServiceUnavailable("restarting"), want: true},
+		{name: "Gateway timeout", err: apierrors.NewTimeoutError("slow", 1), want: true},
+		{name: "Server timeout", err: apierrors.NewServerTimeout(resource, "list", 1), want: true},
+		{name: "Connection reset", err: fmt.Errorf("read: %w", syscall.ECONNRESET), want: true},
+		{name: "Not found", err: apierrors.NewNotFound(resource, "web"), want: false},
+		{name: "Bad request", err: apierrors.NewBadRequest("bad selector"), want: false},
+		{name: "Expired continue token", err: apierrors.NewResourceExpired("too old"), want: false},
+		{name: "Context canceled", err: context.Canceled, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Retryable(tt.err))
+		})
+	}
+}
+
+func TestDelay(t *testing.T) {
+	policy := api.RetryPolicy{MaxAttempts: 10, InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	unavailable := apierrors.NewServiceUnavailable("restarting")
+
+	assert.Equal(t, 100*time.Millisecond, delay(policy, 1, unavailable))
+	assert.Equal(t, 400*time.Millisecond, delay(policy, 3, unavailable))
+	assert.Equal(t, time.Second, delay(policy, 8, unavailable))
+
+	t.Run("Honors Retry-After", func(t *testing.T) {
+		assert.Equal(t, 3*time.Second, delay(policy, 1, apierrors.NewTooManyRequests("slow down", 3)))
+	})
+
+	t.Run("Adds bounded jitter", func(t *testing.T) {
+		policy.Jitter = 0.5
+		got := delay(policy, 1, unavailable)
+		assert.GreaterOrEqual(t, got, 100*time.Millisecond)
+		assert.LessOrEqual(t, got, 150*time.Millisecond)
+	})
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kaudit/val"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	api "github.com/kaudit/k8s_client"
+	"github.com/kaudit/k8s_client/internal/api/cache"
+	"github.com/kaudit/k8s_client/internal/api/classify"
+	"github.com/kaudit/k8s_client/internal/api/options"
+	"github.com/kaudit/k8s_client/selector"
+)
+
+// CachedServiceAPI serves service reads from a shared informer cache.
+// Operations that need the API server, such as snapshots and watches, are delegated
+// to the embedded ServiceAPI.
+type CachedServiceAPI struct {
+	*ServiceAPI
+	lister corelisters.ServiceLister
+	scope  string
+}
+
+// NewCachedServiceAPI creates a new CachedServiceAPI instance backed by the service informer of the given factory.
+// The informer is registered with the factory; the caller is responsible for starting it.
+// A factory restricted to one namespace must be declared with options.WithCacheNamespace.
+// It returns an implementation of the api.ServiceAPI interface.
+func NewCachedServiceAPI(client kubernetes.Interface, factory informers.SharedInformerFactory,
+	opts ...options.Option) api.ServiceAPI {
+
+	return &CachedServiceAPI{
+		ServiceAPI: newServiceAPI(client, opts...),
+		lister:     factory.Core().V1().Services().Lister(),
+		scope:      options.Apply(opts...).CacheNamespace,
+	}
+}
+
+// GetServiceByName retrieves a specific Service by namespace and name from the informer cache.
+//
+// Parameters:
+//   - ctx: Context of the API server request for namespaces outside the cache.
+//   - namespace: Namespace of the service (must be non-empty).
+//   - name: Name of the service (must be non-empty).
+//
+// Returns a copy of the cached *corev1.Service or an error if not found or invalid.
+func (c *CachedServiceAPI) GetServiceByName(ctx context.Context, namespace, name string) (*corev1.Service, error) {
+	if !cache.Covers(c.scope, namespace) {
+		return c.ServiceAPI.GetServiceByName(ctx, namespace, name)
+	}
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, classify.Invalid(kind, namespace, name, fmt.Errorf("invalid namespace: %w", err))
+	}
+	if err := val.ValidateWithTag(name, "required"); err != nil {
+		return nil, classify.Invalid(kind, namespace, name, fmt.Errorf("invalid service name: %w", err))
+	}
+
+	svc, err := c.lister.Services(namespace).Get(name)
+	if err != nil {
+		return nil, classify.Wrap(kind, namespace, name,
+			fmt.Errorf("failed to get service %q in namespace %q: %w", name, namespace, err))
+	}
+
+	return svc.DeepCopy(), nil
+}
+
+// ListServicesByLabel lists cached services by namespace and label selector.
+// Namespaces outside the cache are listed from the API server; otherwise the timeout and
+// limit are validated for interface compatibility but not used.
+//
+// Returns all matching services sorted by name or an error if validation fails.
+func (c *CachedServiceAPI) ListServicesByLabel(ctx context.Context, namespace string, labelSelector string,
+	timeoutSeconds time.Duration, limit int64) ([]corev1.Service, error) {
+
+	if !cache.Covers(c.scope, namespace) {
+		return c.ServiceAPI.ListServicesByLabel(ctx, namespace, labelSelector, timeoutSeconds, limit)
+	}
+	if err := validateInput(namespace, timeoutSeconds, limit); err != nil {
+		return nil, err
+	}
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, classify.Invalid(kind, namespace, "", fmt.Errorf("invalid label selector: %w", err))
+	}
+
+	parsed, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, classify.Invalid(kind, namespace, "", fmt.Errorf("invalid label selector: %w", err))
+	}
+
+	return c.list(namespace, parsed, fields.Everything())
+}
+
+// ListServicesByField lists cached services by namespace and field selector.
+// Namespaces outside the cache are listed from the API server; otherwise the timeout and
+// limit are validated for interface compatibility but not used.
+//
+// Returns all matching services sorted by name or an error if validation fails.
+func (c *CachedServiceAPI) ListServicesByField(ctx context.Context, namespace string, fieldSelector string,
+	timeoutSeconds time.Duration, limit int64) ([]corev1.Service, error) {
+
+	if !cache.Covers(c.scope, namespace) {
+		return c.ServiceAPI.ListServicesByField(ctx, namespace, fieldSelector, timeoutSeconds, limit)
+	}
+	if err := validateInput(namespace, timeoutSeconds, limit); err != nil {
+		return nil, err
+	}
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, classify.Invalid(kind, namespace, "", fmt.Errorf("invalid field selector: %w", err))
+	}
+
+	parsed, err := cache.Fields(selector.Services, fieldSelector)
+	if err != nil {
+		return nil, classify.Invalid(kind, namespace, "", fmt.Errorf("invalid field selector: %w", err))
+	}
+
+	return c.list(namespace, labels.Everything(), parsed)
+}
+
+// ListServicesByQuery lists cached services by namespace and combined query.
+// Queries pinned to a resourceVersion or outside the namespace of the cache are delegated
+// to the API server; otherwise the timeout and limit are validated for interface
+// compatibility but not used.
+//
+// Returns all matching services sorted by name or an error if validation fails.
+func (c *CachedServiceAPI) ListServicesByQuery(ctx context.Context, namespace string,
+	query api.ListQuery) ([]corev1.Service, error) {
+
+	if !cache.Servable(query) || !cache.Covers(c.scope, namespace) {
+		return c.ServiceAPI.ListServicesByQuery(ctx, namespace, query)
+	}
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, classify.Invalid(kind, namespace, "", fmt.Errorf("invalid namespace: %w", err))
+	}
+	if err := val.ValidateStruct(query); err != nil {
+		return nil, classify.Invalid(kind, namespace, "", fmt.Errorf("invalid list query: %w", err))
+	}
+
+	labelSelector, fieldSelector, err := cache.Selectors(selector.Services, query)
+	if err != nil {
+		return nil, classify.Invalid(kind, namespace, "", err)
+	}
+
+	return c.list(namespace, labelSelector, fieldSelector)
+}
+
+// list returns copies of the cached services in namespace matching both selectors.
+func (c *CachedServiceAPI) list(namespace string, labelSelector labels.Selector,
+	fieldSelector fields.Selector) ([]corev1.Service, error) {
+
+	services, err := c.lister.Services(namespace).List(labelSelector)
+	if err != nil {
+		return nil, classify.Wrap(kind, namespace, "",
+			fmt.Errorf("failed to list cached services in namespace %q: %w", namespace, err))
+	}
+
+	return cache.Items(services, fieldSelector, serviceFields), nil
+}
+
+// serviceFields returns the selectable fields of a service as evaluated by the API server.
+func serviceFields(svc *corev1.Service) fields.Set {
+	set := cache.ObjectMetaFields(svc)
+	set["spec.type"] = string(svc.Spec.Type)
+
+	return set
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+
+	api "github.com/kaudit/k8s_client"
+	"github.com/kaudit/k8s_client/selector"
+)
+
+func newSyncedCachedServiceAPI(t *testing.T, objects ...*corev1.Service) *CachedServiceAPI {
+	t.Helper()
+
+	fakeClient := fake.NewClientset()
+	for _, obj := range objects {
+		_, err := fakeClient.CoreV1().Services(obj.Namespace).Create(context.Background(), obj, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	factory := informers.NewSharedInformerFactory(fakeClient, 0)
+	serviceAPI := NewCachedServiceAPI(fakeClient, factory)
+
+	stopCh := make(chan struct{})
+	t.Cleanup(func() {
+		close(stopCh)
+		factory.Shutdown()
+	})
+	factory.Start(stopCh)
+	for _, synced := range factory.WaitForCacheSync(stopCh) {
+		require.True(t, synced)
+	}
+
+	impl, ok := serviceAPI.(*CachedServiceAPI)
+	require.True(t, ok)
+
+	return impl
+}
+
+func TestCachedServiceAPI(t *testing.T) {
+	serviceAPI := newSyncedCachedServiceAPI(t,
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "test-namespace", Labels: map[string]string{"app": "web"}},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "test-namespace", Labels: map[string]string{"app": "web"}},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeNodePort},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "db-1", Namespace: "other-namespace", Labels: map[string]string{"app": "db"}},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeNodePort},
+		},
+	)
+	ctx := context.Background()
+
+	t.Run("Get cached service", func(t *testing.T) {
+		svc, err := serviceAPI.GetServiceByName(ctx, "test-namespace", "web-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "web-1", svc.Name)
+
+		svc.Labels["app"] = "mutated"
+		again, err := serviceAPI.GetServiceByName(ctx, "test-namespace", "web-1")
+		require.NoError(t, err)
+		assert.Equal(t, "web", again.Labels["app"])
+	})
+
+	t.Run("Get missing service", func(t *testing.T) {
+		svc, err := serviceAPI.GetServiceByName(ctx, "test-namespace", "missing")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get service")
+		assert.ErrorIs(t, err, api.ErrNotFound)
+		assert.Nil(t, svc)
+	})
+
+	t.Run("List by label sorted by name", func(t *testing.T) {
+		services, err := serviceAPI.ListServicesByLabel(ctx, "test-namespace", "app=web", 2*time.Second, 1)
+
+		require.NoError(t, err)
+		require.Len(t, services, 2)
+		assert.Equal(t, "web-1", services[0].Name)
+		assert.Equal(t, "web-2", services[1].Name)
+	})
+
+	t.Run("List by field", func(t *testing.T) {
+		services, err := serviceAPI.ListServicesByField(ctx, "test-namespace", "spec.type=NodePort", 2*time.Second, 1)
+
+		require.NoError(t, err)
+		require.Len(t, services, 1)
+		assert.Equal(t, "web-1", services[0].Name)
+	})
+
+	t.Run("List by query combining selectors", func(t *testing.T) {
+		items, err := serviceAPI.ListServicesByQuery(ctx, "test-namespace", api.ListQuery{
+			LabelSelector:  "app=web",
+			FieldSelector:  "spec.type=NodePort",
+			TimeoutSeconds: 2 * time.Second,
+			Limit:          1,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, "web-1", items[0].Name)
+	})
+
+	t.Run("Invalid input", func(t *testing.T) {
+		_, err := serviceAPI.ListServicesByLabel(ctx, "", "app=web", 2*time.Second, 1)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid namespace")
+
+		_, err = serviceAPI.ListServicesByField(ctx, "test-namespace", "spec.unknown=x", 2*time.Second, 1)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid field selector")
+	})
+
+	t.Run("Unsupported field", func(t *testing.T) {
+		for _, fieldSelector := range []string{"spec.nodeName=x", "spec.nodeName!=x"} {
+			_, err := serviceAPI.ListServicesByField(ctx, "test-namespace", fieldSelector, 2*time.Second, 1)
+			require.ErrorIs(t, err, api.ErrInvalidInput)
+			require.ErrorIs(t, err, selector.ErrUnsupportedField)
+
+			_, err = serviceAPI.ListServicesByQuery(ctx, "test-namespace", api.ListQuery{
+				FieldSelector:  fieldSelector,
+				TimeoutSeconds: 2 * time.Second,
+				Limit:          1,
+			})
+			require.ErrorIs(t, err, api.ErrInvalidInput)
+			require.ErrorIs(t, err, selector.ErrUnsupportedField)
+		}
+	})
+}
@@ -2,14 +2,23 @@ package service
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes/fake"
+	metadatafake "k8s.io/client-go/metadata/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	api "github.com/kaudit/k8s_client"
+	"github.com/kaudit/k8s_client/internal/api/options"
 )
 
 func TestServiceAPI_New(t *testing.T) {
@@ -125,6 +134,7 @@ func TestServiceAPI_GetServiceByName(t *testing.T) {
 		serviceName   string
 		wantErr       bool
 		errorContains string
+		wantErrIs     error
 	}{
 		{
 			name:        "Successfully get service",
@@ -138,6 +148,7 @@ func TestServiceAPI_GetServiceByName(t *testing.T) {
 			serviceName:   "test-service",
 			wantErr:       true,
 			errorContains: "invalid namespace",
+			wantErrIs:     api.ErrInvalidInput,
 		},
 		{
 			name:          "Empty service name",
@@ -145,6 +156,7 @@ func TestServiceAPI_GetServiceByName(t *testing.T) {
 			serviceName:   "",
 			wantErr:       true,
 			errorContains: "invalid service name",
+			wantErrIs:     api.ErrInvalidInput,
 		},
 		{
 			name:          "Service not found",
@@ -152,6 +164,7 @@ func TestServiceAPI_GetServiceByName(t *testing.T) {
 			serviceName:   "nonexistent-service",
 			wantErr:       true,
 			errorContains: "failed to get service",
+			wantErrIs:     api.ErrNotFound,
 		},
 	}
 
@@ -165,6 +178,10 @@ func TestServiceAPI_GetServiceByName(t *testing.T) {
 				if tt.errorContains != "" {
 					assert.Contains(t, err.Error(), tt.errorContains)
 				}
+				require.ErrorIs(t, err, tt.wantErrIs)
+				var apiErr *api.Error
+				require.ErrorAs(t, err, &apiErr)
+				assert.Equal(t, "Service", apiErr.Kind)
 				assert.Nil(t, service)
 			} else {
 				require.NoError(t, err)
@@ -510,3 +527,409 @@ func TestServiceAPI_ListServicesByField(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceAPI_ListServicesByQuery(t *testing.T) {
+	// Serve two pages and record the options of every request
+	fakeClient := fake.NewClientset()
+	var requests []metav1.ListOptions
+	fakeClient.PrependReactor("list", "services", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		opts := action.(k8stesting.ListActionImpl).GetListOptions()
+		requests = append(requests, opts)
+		if opts.Continue == "" {
+			return true, &corev1.ServiceList{
+				ListMeta: metav1.ListMeta{ResourceVersion: "10", Continue: "token"},
+				Items:    []corev1.Service{{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "test-namespace", Labels: map[string]string{"app": "web"}}}},
+			}, nil
+		}
+		return true, &corev1.ServiceList{
+			ListMeta: metav1.ListMeta{ResourceVersion: "10"},
+			Items:    []corev1.Service{{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "test-namespace", Labels: map[string]string{"app": "web"}}}},
+		}, nil
+	})
+
+	serviceAPI := NewServiceAPI(fakeClient)
+
+	tests := []struct {
+		name          string
+		namespace     string
+		query         api.ListQuery
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:      "Combined selectors with resourceVersion",
+			namespace: "test-namespace",
+			query: api.ListQuery{
+				LabelSelector:        "app=web",
+				FieldSelector:        "spec.type=NodePort",
+				ResourceVersion:      "5",
+				ResourceVersionMatch: metav1.ResourceVersionMatchNotOlderThan,
+				TimeoutSeconds:       2 * time.Second,
+				Limit:                1,
+			},
+			wantErr: false,
+		},
+		{
+			name:          "Empty namespace",
+			namespace:     "",
+			query:         api.ListQuery{TimeoutSeconds: 2 * time.Second, Limit: 1},
+			wantErr:       true,
+			errorContains: "invalid namespace",
+		},
+		{
+			name:          "Invalid label selector",
+			namespace:     "test-namespace",
+			query:         api.ListQuery{LabelSelector: "app in (web", TimeoutSeconds: 2 * time.Second, Limit: 1},
+			wantErr:       true,
+			errorContains: "invalid list query",
+		},
+		{
+			name:          "Invalid field selector",
+			namespace:     "test-namespace",
+			query:         api.ListQuery{FieldSelector: "spec.unknown=x", TimeoutSeconds: 2 * time.Second, Limit: 1},
+			wantErr:       true,
+			errorContains: "invalid list query",
+		},
+		{
+			name:      "Match without resourceVersion",
+			namespace: "test-namespace",
+			query: api.ListQuery{
+				ResourceVersionMatch: metav1.ResourceVersionMatchExact,
+				TimeoutSeconds:       2 * time.Second,
+				Limit:                1,
+			},
+			wantErr:       true,
+			errorContains: "invalid list query",
+		},
+		{
+			name:          "Invalid limit",
+			namespace:     "test-namespace",
+			query:         api.ListQuery{TimeoutSeconds: 2 * time.Second},
+			wantErr:       true,
+			errorContains: "invalid list query",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests = nil
+			ctx := context.Background()
+			items, err := serviceAPI.ListServicesByQuery(ctx, tt.namespace, tt.query)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, items)
+				assert.Empty(t, requests)
+				return
+			}
+
+			require.NoError(t, err)
+			names := make([]string, 0, len(items))
+			for _, item := range items {
+				names = append(names, item.Name)
+			}
+			assert.Equal(t, []string{"a", "b"}, names)
+			require.Len(t, requests, 2)
+			assert.Equal(t, tt.query.LabelSelector, requests[0].LabelSelector)
+			assert.Equal(t, tt.query.FieldSelector, requests[0].FieldSelector)
+			assert.Equal(t, "5", requests[0].ResourceVersion)
+			assert.Equal(t, metav1.ResourceVersionMatchNotOlderThan, requests[0].ResourceVersionMatch)
+			assert.Equal(t, "token", requests[1].Continue)
+			assert.Empty(t, requests[1].ResourceVersion)
+			assert.Empty(t, requests[1].ResourceVersionMatch)
+		})
+	}
+}
+
+func TestServiceAPI_ListServicesSnapshot(t *testing.T) {
+	// Serve a first page, expire its continue token once and then serve the full list
+	fakeClient := fake.NewClientset()
+	calls := 0
+	fakeClient.PrependReactor("list", "services", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		calls++
+		opts := action.(k8stesting.ListActionImpl).GetListOptions()
+		switch {
+		case calls == 1:
+			return true, &corev1.ServiceList{
+				ListMeta: metav1.ListMeta{ResourceVersion: "10", Continue: "token"},
+				Items:    []corev1.Service{{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "test-namespace"}}},
+			}, nil
+		case opts.Continue != "":
+			return true, nil, apierrors.NewResourceExpired("continue token too old")
+		default:
+			return true, &corev1.ServiceList{
+				ListMeta: metav1.ListMeta{ResourceVersion: "20"},
+				Items: []corev1.Service{
+					{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "test-namespace"}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "test-namespace"}},
+				},
+			}, nil
+		}
+	})
+
+	serviceAPI := NewServiceAPI(fakeClient)
+
+	tests := []struct {
+		name          string
+		namespace     string
+		opts          api.SnapshotOptions
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:      "Restarts after expired continue token",
+			namespace: "test-namespace",
+			opts:      api.SnapshotOptions{TimeoutSeconds: 2 * time.Second, Limit: 1},
+			wantErr:   false,
+		},
+		{
+			name:          "Empty namespace",
+			namespace:     "",
+			opts:          api.SnapshotOptions{TimeoutSeconds: 2 * time.Second, Limit: 1},
+			wantErr:       true,
+			errorContains: "invalid namespace",
+		},
+		{
+			name:          "Invalid label selector",
+			namespace:     "test-namespace",
+			opts:          api.SnapshotOptions{LabelSelector: "app in (web", TimeoutSeconds: 2 * time.Second, Limit: 1},
+			wantErr:       true,
+			errorContains: "invalid snapshot options",
+		},
+		{
+			name:          "Invalid limit",
+			namespace:     "test-namespace",
+			opts:          api.SnapshotOptions{TimeoutSeconds: 2 * time.Second},
+			wantErr:       true,
+			errorContains: "invalid snapshot options",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			snapshot, err := serviceAPI.ListServicesSnapshot(ctx, tt.namespace, tt.opts)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, snapshot)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "20", snapshot.ResourceVersion)
+			assert.Equal(t, 1, snapshot.Restarts)
+			assert.True(t, snapshot.Complete)
+			names := make([]string, 0, len(snapshot.Items))
+			for _, item := range snapshot.Items {
+				names = append(names, item.Name)
+			}
+			assert.Equal(t, []string{"a", "b"}, names)
+		})
+	}
+}
+
+func TestServiceAPI_WatchServices(t *testing.T) {
+	fakeClient := fake.NewClientset()
+	serviceAPI := NewServiceAPI(fakeClient)
+
+	t.Run("Delivers added event", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events, err := serviceAPI.WatchServices(ctx, "test-namespace", api.WatchOptions{})
+		require.NoError(t, err)
+
+		_, err = fakeClient.CoreV1().Services("test-namespace").Create(ctx,
+			&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "watched", Namespace: "test-namespace"}}, metav1.CreateOptions{})
+		require.NoError(t, err)
+
+		select {
+		case event := <-events:
+			assert.Equal(t, api.EventAdded, event.Type)
+			assert.Equal(t, "watched", event.Object.Name)
+		case <-time.After(5 * time.Second):
+			t.Fatal("no event received")
+		}
+	})
+
+	t.Run("Empty namespace", func(t *testing.T) {
+		events, err := serviceAPI.WatchServices(context.Background(), "", api.WatchOptions{})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid namespace")
+		assert.Nil(t, events)
+	})
+
+	t.Run("Invalid label selector", func(t *testing.T) {
+		events, err := serviceAPI.WatchServices(context.Background(), "test-namespace", api.WatchOptions{LabelSelector: "app in (web"})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid watch options")
+		assert.Nil(t, events)
+	})
+}
+
+func TestServiceAPI_ListServicesMetadata(t *testing.T) {
+	scheme := metadatafake.NewTestScheme()
+	require.NoError(t, metav1.AddMetaToScheme(scheme))
+
+	newMetadata := func(name string, labels map[string]string) *metav1.PartialObjectMetadata {
+		return &metav1.PartialObjectMetadata{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: name, Labels: labels},
+		}
+	}
+	metadataClient := metadatafake.NewSimpleMetadataClient(scheme,
+		newMetadata("web", map[string]string{"app": "web"}),
+		newMetadata("db", map[string]string{"app": "db"}),
+	)
+
+	tests := []struct {
+		name           string
+		api            *ServiceAPI
+		namespace      string
+		labelSelector  string
+		timeoutSeconds time.Duration
+		limit          int64
+		expectedNames  []string
+		wantErr        bool
+		errorContains  string
+	}{
+		{
+			name:           "List all metadata",
+			api:            newServiceAPI(fake.NewClientset(), options.WithMetadataClient(metadataClient)),
+			namespace:      "test-namespace",
+			timeoutSeconds: 2 * time.Second,
+			limit:          1,
+			expectedNames:  []string{"db", "web"},
+		},
+		{
+			name:           "List metadata by label",
+			api:            newServiceAPI(fake.NewClientset(), options.WithMetadataClient(metadataClient)),
+			namespace:      "test-namespace",
+			labelSelector:  "app=web",
+			timeoutSeconds: 2 * time.Second,
+			limit:          1,
+			expectedNames:  []string{"web"},
+		},
+		{
+			name:           "Empty namespace",
+			api:            newServiceAPI(fake.NewClientset(), options.WithMetadataClient(metadataClient)),
+			namespace:      "",
+			timeoutSeconds: 2 * time.Second,
+			limit:          1,
+			wantErr:        true,
+			errorContains:  "invalid namespace",
+		},
+		{
+			name:           "Invalid label selector",
+			api:            newServiceAPI(fake.NewClientset(), options.WithMetadataClient(metadataClient)),
+			namespace:      "test-namespace",
+			labelSelector:  "app in (web",
+			timeoutSeconds: 2 * time.Second,
+			limit:          1,
+			wantErr:        true,
+			errorContains:  "invalid label selector",
+		},
+		{
+			name:           "Missing metadata client",
+			api:            newServiceAPI(fake.NewClientset()),
+			namespace:      "test-namespace",
+			timeoutSeconds: 2 * time.Second,
+			limit:          1,
+			wantErr:        true,
+			errorContains:  options.ErrNoMetadataClient.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := tt.api.ListServicesMetadata(context.Background(), tt.namespace, tt.labelSelector,
+				tt.timeoutSeconds, tt.limit)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, items)
+				return
+			}
+
+			require.NoError(t, err)
+			names := make([]string, 0, len(items))
+			for _, item := range items {
+				names = append(names, item.Name)
+			}
+			assert.ElementsMatch(t, tt.expectedNames, names)
+		})
+	}
+}
+
+func TestServiceAPI_RetryPolicy(t *testing.T) {
+	// Fail the first calls of every verb and count all attempts
+	fakeClient := fake.NewClientset(&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "test-namespace", Labels: map[string]string{"app": "web"}}})
+	var getFailures, listFailures, getCalls int
+	var getErr error
+	fakeClient.PrependReactor("get", "services", func(k8stesting.Action) (bool, runtime.Object, error) {
+		getCalls++
+		if getFailures > 0 {
+			getFailures--
+			return true, nil, getErr
+		}
+		return false, nil, nil
+	})
+	fakeClient.PrependReactor("list", "services", func(k8stesting.Action) (bool, runtime.Object, error) {
+		if listFailures > 0 {
+			listFailures--
+			return true, nil, apierrors.NewServiceUnavailable("restarting")
+		}
+		return false, nil, nil
+	})
+
+	policy := api.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	serviceAPI := NewServiceAPI(fakeClient, options.WithRetryPolicy(policy))
+	ctx := context.Background()
+
+	t.Run("Retries transient failures", func(t *testing.T) {
+		getFailures, listFailures, getCalls = 2, 2, 0
+		getErr = apierrors.NewTooManyRequests("slow down", 0)
+
+		got, err := serviceAPI.GetServiceByName(ctx, "test-namespace", "web")
+		require.NoError(t, err)
+		assert.Equal(t, "web", got.Name)
+		assert.Equal(t, 3, getCalls)
+
+		items, err := serviceAPI.ListServicesByLabel(ctx, "test-namespace", "app=web", 2*time.Second, 1)
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+	})
+
+	t.Run("Gives up after max attempts", func(t *testing.T) {
+		listFailures = 3
+
+		_, err := serviceAPI.ListServicesByLabel(ctx, "test-namespace", "app=web", 2*time.Second, 1)
+		require.Error(t, err)
+		assert.True(t, apierrors.IsServiceUnavailable(err))
+	})
+
+	t.Run("Does not retry permanent errors", func(t *testing.T) {
+		getFailures, getCalls = 3, 0
+		getErr = apierrors.NewForbidden(schema.GroupResource{Resource: "services"}, "web", errors.New("denied"))
+
+		_, err := serviceAPI.GetServiceByName(ctx, "test-namespace", "web")
+		require.ErrorIs(t, err, api.ErrForbidden)
+		assert.Equal(t, 1, getCalls)
+	})
+
+	t.Run("Attempts once without policy", func(t *testing.T) {
+		getFailures, getCalls = 1, 0
+		getErr = apierrors.NewTooManyRequests("slow down", 0)
+
+		_, err := NewServiceAPI(fakeClient).GetServiceByName(ctx, "test-namespace", "web")
+		require.Error(t, err)
+		assert.True(t, apierrors.IsTooManyRequests(err))
+		assert.Equal(t, 1, getCalls)
+	})
+}
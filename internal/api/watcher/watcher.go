@@ -0,0 +1,198 @@
+// Package watcher implements the resumable watch loop shared by the resource APIs.
+// It converts raw watch events into typed api.WatchEvent values, follows bookmarks
+// and reconnects from the last observed resourceVersion when the stream ends.
+package watcher
+
+import (
+	"context"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+
+	api "github.com/kaudit/k8s_client"
+)
+
+const (
+	// initialRetryDelay is the delay before the first reconnect attempt after a failed watch.
+	initialRetryDelay = 500 * time.Millisecond
+	// maxRetryDelay caps the exponential delay between failed reconnect attempts.
+	maxRetryDelay = 30 * time.Second
+)
+
+// Object is the constraint satisfied by typed Kubernetes objects such as *corev1.Pod.
+type Object interface {
+	runtime.Object
+	metav1.Object
+}
+
+// WatchFunc opens a raw watch for the given list options.
+type WatchFunc func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+
+// Watch opens a watch and delivers typed events on the returned channel.
+//
+// Parameters:
+//   - ctx: Context controlling the lifetime of the watch; the channel is closed when it is done.
+//   - opts: Validated watch options providing selectors and the starting resourceVersion.
+//   - start: Function opening a raw watch.
+//
+// The initial watch call is performed synchronously and its error is returned. Afterwards the
+// watch is reopened from the last observed resourceVersion whenever the stream ends, and from
+// the current state when that resourceVersion has expired. Reconnects after failed watch calls
+// and streams ending in an error are delayed with exponential backoff, which is reset by the
+// next event received.
+func Watch[T Object](ctx context.Context, opts api.WatchOptions, start WatchFunc) (<-chan api.WatchEvent[T], error) {
+	listOpts := metav1.ListOptions{
+		LabelSelector:       opts.LabelSelector,
+		FieldSelector:       opts.FieldSelector,
+		ResourceVersion:     opts.ResourceVersion,
+		AllowWatchBookmarks: true,
+	}
+
+	w, err := start(ctx, listOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan api.WatchEvent[T])
+
+	go run(ctx, w, listOpts, start, events)
+
+	return events, nil
+}
+
+// run consumes watch streams until ctx is done, reopening them as needed, and closes events on exit.
+func run[T Object](ctx context.Context, w watch.Interface, opts metav1.ListOptions,
+	start WatchFunc, events chan<- api.WatchEvent[T]) {
+
+	defer close(events)
+
+	retry := &backoff{delay: initialRetryDelay}
+
+	for {
+		resourceVersion, err := consume(ctx, w, opts.ResourceVersion, events, retry)
+		w.Stop()
+
+		opts.ResourceVersion = resourceVersion
+		if isExpired(err) {
+			opts.ResourceVersion = ""
+		} else if err != nil && !retry.wait(ctx) {
+			return
+		}
+
+		var ok bool
+		if w, ok = reconnect(ctx, &opts, start, retry); !ok {
+			return
+		}
+	}
+}
+
+// reconnect reopens the watch, waiting for retry after each failed attempt.
+// It returns false once ctx is done.
+func reconnect(ctx context.Context, opts *metav1.ListOptions, start WatchFunc, retry *backoff) (watch.Interface, bool) {
+	for {
+		if ctx.Err() != nil {
+			return nil, false
+		}
+
+		w, err := start(ctx, *opts)
+		if err == nil {
+			return w, true
+		}
+
+		if isExpired(err) {
+			opts.ResourceVersion = ""
+		}
+
+		if !retry.wait(ctx) {
+			return nil, false
+		}
+	}
+}
+
+// consume forwards typed events from w until the stream ends, fails or ctx is done, resetting
+// retry whenever an event is received. It returns the last observed resourceVersion and the
+// error of a failed stream.
+func consume[T Object](ctx context.Context, w watch.Interface, resourceVersion string,
+	events chan<- api.WatchEvent[T], retry *backoff) (string, error) {
+
+	for {
+		select {
+		case <-ctx.Done():
+			return resourceVersion, nil
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return resourceVersion, nil
+			}
+
+			var stop bool
+			var err error
+			if resourceVersion, stop, err = handle(ctx, event, resourceVersion, events); stop {
+				return resourceVersion, err
+			}
+
+			retry.reset()
+		}
+	}
+}
+
+// handle processes a single event observed after resourceVersion. It returns the resourceVersion
+// observed afterwards, whether the stream must be stopped and, for error events, their error.
+func handle[T Object](ctx context.Context, event watch.Event, resourceVersion string,
+	events chan<- api.WatchEvent[T]) (string, bool, error) {
+
+	switch event.Type {
+	case watch.Bookmark:
+		if accessor, err := meta.Accessor(event.Object); err == nil {
+			return accessor.GetResourceVersion(), false, nil
+		}
+	case watch.Error:
+		return resourceVersion, true, apierrors.FromObject(event.Object)
+	case watch.Added, watch.Modified, watch.Deleted:
+		obj, ok := event.Object.(T)
+		if !ok {
+			return resourceVersion, false, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return obj.GetResourceVersion(), true, nil
+		case events <- api.WatchEvent[T]{Type: api.EventType(event.Type), Object: obj}:
+			return obj.GetResourceVersion(), false, nil
+		}
+	}
+
+	return resourceVersion, false, nil
+}
+
+// backoff is the exponential delay between failed watch attempts.
+type backoff struct {
+	delay time.Duration
+}
+
+// wait sleeps for the current delay and doubles it for the next failure.
+// It returns false once ctx is done.
+func (b *backoff) wait(ctx context.Context) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(b.delay):
+	}
+
+	b.delay = min(2*b.delay, maxRetryDelay)
+
+	return true
+}
+
+// reset restores the initial delay after the watch delivered an event.
+func (b *backoff) reset() {
+	b.delay = initialRetryDelay
+}
+
+// isExpired reports whether err signals an expired resourceVersion.
+func isExpired(err error) bool {
+	return apierrors.IsResourceExpired(err) || apierrors.IsGone(err)
+}
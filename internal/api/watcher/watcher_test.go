@@ -0,0 +1,186 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	api "github.com/kaudit/k8s_client"
+)
+
+// fakeWatches hands out a new watch.FakeWatcher for every call and records the options used.
+type fakeWatches struct {
+	mu       sync.Mutex
+	opts     []metav1.ListOptions
+	watchers chan *watch.FakeWatcher
+}
+
+func newFakeWatches() *fakeWatches {
+	return &fakeWatches{watchers: make(chan *watch.FakeWatcher, 10)}
+}
+
+func (f *fakeWatches) start(_ context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	f.mu.Lock()
+	f.opts = append(f.opts, opts)
+	f.mu.Unlock()
+
+	w := watch.NewFake()
+	f.watchers <- w
+	return w, nil
+}
+
+func (f *fakeWatches) next(t *testing.T) *watch.FakeWatcher {
+	t.Helper()
+	select {
+	case w := <-f.watchers:
+		return w
+	case <-time.After(5 * time.Second):
+		t.Fatal("watch was not reopened")
+		return nil
+	}
+}
+
+func (f *fakeWatches) options(i int) metav1.ListOptions {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.opts[i]
+}
+
+func pod(name, resourceVersion string) *corev1.Pod {
+	return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, ResourceVersion: resourceVersion}}
+}
+
+func receive(t *testing.T, events <-chan api.WatchEvent[*corev1.Pod]) api.WatchEvent[*corev1.Pod] {
+	t.Helper()
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event received")
+		return api.WatchEvent[*corev1.Pod]{}
+	}
+}
+
+func TestWatch(t *testing.T) {
+	t.Run("delivers typed events and resumes after disconnect", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		watches := newFakeWatches()
+		events, err := Watch[*corev1.Pod](ctx, api.WatchOptions{LabelSelector: "app=web"}, watches.start)
+		require.NoError(t, err)
+
+		first := watches.next(t)
+		first.Add(pod("a", "5"))
+		event := receive(t, events)
+		assert.Equal(t, api.EventAdded, event.Type)
+		assert.Equal(t, "a", event.Object.Name)
+
+		first.Modify(pod("a", "6"))
+		assert.Equal(t, api.EventModified, receive(t, events).Type)
+
+		first.Action(watch.Bookmark, pod("", "9"))
+		first.Stop()
+
+		second := watches.next(t)
+		second.Delete(pod("a", "10"))
+		assert.Equal(t, api.EventDeleted, receive(t, events).Type)
+
+		initial := watches.options(0)
+		assert.Equal(t, "app=web", initial.LabelSelector)
+		assert.True(t, initial.AllowWatchBookmarks)
+		assert.Empty(t, initial.ResourceVersion)
+		assert.Equal(t, "9", watches.options(1).ResourceVersion)
+	})
+
+	t.Run("restarts from current state when resource version expired", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		watches := newFakeWatches()
+		events, err := Watch[*corev1.Pod](ctx, api.WatchOptions{ResourceVersion: "3"}, watches.start)
+		require.NoError(t, err)
+
+		first := watches.next(t)
+		first.Add(pod("a", "5"))
+		receive(t, events)
+
+		status := apierrors.NewResourceExpired("too old resource version").Status()
+		first.Error(&status)
+
+		second := watches.next(t)
+		second.Add(pod("a", "20"))
+		assert.Equal(t, "20", receive(t, events).Object.ResourceVersion)
+
+		assert.Equal(t, "3", watches.options(0).ResourceVersion)
+		assert.Empty(t, watches.options(1).ResourceVersion)
+	})
+
+	t.Run("backs off when stream ends in error", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		watches := newFakeWatches()
+		events, err := Watch[*corev1.Pod](ctx, api.WatchOptions{}, watches.start)
+		require.NoError(t, err)
+
+		status := apierrors.NewInternalError(errors.New("etcd unavailable")).Status()
+
+		failed := time.Now()
+		watches.next(t).Error(&status)
+		second := watches.next(t)
+		assert.GreaterOrEqual(t, time.Since(failed), initialRetryDelay)
+
+		failed = time.Now()
+		second.Error(&status)
+		third := watches.next(t)
+		assert.GreaterOrEqual(t, time.Since(failed), 2*initialRetryDelay)
+
+		third.Add(pod("a", "5"))
+		receive(t, events)
+
+		failed = time.Now()
+		third.Error(&status)
+		watches.next(t)
+		assert.Less(t, time.Since(failed), 2*initialRetryDelay)
+	})
+
+	t.Run("closes channel when context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		watches := newFakeWatches()
+		events, err := Watch[*corev1.Pod](ctx, api.WatchOptions{}, watches.start)
+		require.NoError(t, err)
+
+		first := watches.next(t)
+		cancel()
+
+		select {
+		case _, ok := <-events:
+			assert.False(t, ok)
+		case <-time.After(5 * time.Second):
+			t.Fatal("channel was not closed")
+		}
+		assert.True(t, first.IsStopped())
+	})
+
+	t.Run("returns initial watch error", func(t *testing.T) {
+		start := func(context.Context, metav1.ListOptions) (watch.Interface, error) {
+			return nil, errors.New("watch failed")
+		}
+
+		events, err := Watch[*corev1.Pod](context.Background(), api.WatchOptions{}, start)
+
+		require.Error(t, err)
+		assert.Nil(t, events)
+	})
+}
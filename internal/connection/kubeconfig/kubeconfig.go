@@ -6,6 +6,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 
 	api "github.com/kaudit/k8s_client"
 )
@@ -14,49 +15,111 @@ import (
 // It loads kubeconfig data on demand and constructs both typed and dynamic clients from it.
 type KubeConfigConnection struct {
 	authLoader api.K8sAuthLoader
+	overrides  clientcmd.ConfigOverrides
+}
+
+// Option configures a KubeConfigConnection.
+type Option func(*KubeConfigConnection)
+
+// WithOverrides selects the context, cluster server, user and default namespace used
+// instead of those of the kubeconfig's current-context.
+func WithOverrides(overrides clientcmd.ConfigOverrides) Option {
+	return func(k *KubeConfigConnection) {
+		k.overrides = overrides
+	}
 }
 
 // NewKubeConfigConnection returns an implementation of the auth.Authenticator interface.
 // It uses the provided K8sAuthLoader to load kubeconfig data on demand.
-func NewKubeConfigConnection(loader api.K8sAuthLoader) *KubeConfigConnection {
-	return &KubeConfigConnection{authLoader: loader}
+func NewKubeConfigConnection(loader api.K8sAuthLoader, opts ...Option) *KubeConfigConnection {
+	k := &KubeConfigConnection{authLoader: loader}
+	for _, opt := range opts {
+		opt(k)
+	}
+
+	return k
 }
 
 // NativeAPI returns a typed Kubernetes client constructed from kubeconfig data.
 // It returns an error if loading the configuration or creating the client fails.
 func (k *KubeConfigConnection) NativeAPI() (kubernetes.Interface, error) {
+	r, err := k.RestConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	i, err := kubernetes.NewForConfig(r)
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes.NewForConfig failed: %w", err)
+	}
+
+	return i, nil
+}
+
+// RestConfig returns the *rest.Config described by the loaded kubeconfig data.
+// It returns an error if loading or parsing the configuration fails.
+func (k *KubeConfigConnection) RestConfig() (*rest.Config, error) {
+	r, _, err := k.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Config returns the *rest.Config described by the loaded kubeconfig data together with
+// the default namespace of the selected context, both with the connection overrides applied.
+// It returns an error if loading or parsing the configuration fails.
+func (k *KubeConfigConnection) Config() (*rest.Config, string, error) {
 	kubeConfig, err := k.authLoader.Load()
 	if err != nil {
-		return nil, fmt.Errorf("authLoader.Load failed: %w", err)
+		return nil, "", fmt.Errorf("authLoader.Load failed: %w", err)
 	}
 
-	r, err := getRestConfig(kubeConfig)
+	r, namespace, err := getRestConfig(kubeConfig, &k.overrides)
 	if err != nil {
-		return nil, fmt.Errorf("getRestConfig failed: %w", err)
+		return nil, "", fmt.Errorf("getRestConfig failed: %w", err)
 	}
 
-	i, err := kubernetes.NewForConfig(r)
+	return r, namespace, nil
+}
+
+// LoadConfig loads and parses the kubeconfig data of loader without selecting a context.
+// It returns an error if loading or parsing the configuration fails.
+func LoadConfig(loader api.K8sAuthLoader) (*clientcmdapi.Config, error) {
+	kubeConfig, err := loader.Load()
 	if err != nil {
-		return nil, fmt.Errorf("kubernetes.NewForConfig failed: %w", err)
+		return nil, fmt.Errorf("authLoader.Load failed: %w", err)
 	}
 
-	return i, nil
+	cfg, err := clientcmd.Load(kubeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("clientcmd.Load failed: %w", err)
+	}
+
+	return cfg, nil
 }
 
-// getRestConfig constructs a *rest.Config object from the given kubeconfig data.
+// getRestConfig constructs a *rest.Config object from the given kubeconfig data and
+// returns it with the default namespace of the selected context.
 // It returns an error if the kubeconfig is invalid or cannot be parsed.
-func getRestConfig(kubeConfig []byte) (*rest.Config, error) {
+func getRestConfig(kubeConfig []byte, overrides *clientcmd.ConfigOverrides) (*rest.Config, string, error) {
 	cfg, err := clientcmd.Load(kubeConfig)
 	if err != nil {
-		return nil, fmt.Errorf("clientcmd.Load failed: %w", err)
+		return nil, "", fmt.Errorf("clientcmd.Load failed: %w", err)
 	}
 
-	clientCfg := clientcmd.NewDefaultClientConfig(*cfg, &clientcmd.ConfigOverrides{})
+	clientCfg := clientcmd.NewDefaultClientConfig(*cfg, overrides)
 
 	restCfg, err := clientCfg.ClientConfig()
 	if err != nil {
-		return nil, fmt.Errorf("clientCfg.ClientConfig failed: %w", err)
+		return nil, "", fmt.Errorf("clientCfg.ClientConfig failed: %w", err)
+	}
+
+	namespace, _, err := clientCfg.Namespace()
+	if err != nil {
+		return nil, "", fmt.Errorf("clientCfg.Namespace failed: %w", err)
 	}
 
-	return restCfg, nil
+	return restCfg, namespace, nil
 }
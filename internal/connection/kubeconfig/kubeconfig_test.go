@@ -6,6 +6,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 
 	mocksauth "github.com/kaudit/k8s_client/mocks/K8sAuthLoader"
 )
@@ -28,7 +30,7 @@ func TestGetRestConfig(t *testing.T) {
 		invalidKubeconfig := []byte(`invalid yaml`)
 
 		// Act
-		config, err := getRestConfig(invalidKubeconfig)
+		config, _, err := getRestConfig(invalidKubeconfig, &clientcmd.ConfigOverrides{})
 
 		// Assert
 		require.Error(t, err)
@@ -57,7 +59,7 @@ users:
 `)
 
 		// Act
-		config, err := getRestConfig(noContextKubeconfig)
+		config, _, err := getRestConfig(noContextKubeconfig, &clientcmd.ConfigOverrides{})
 
 		// Assert
 		require.Error(t, err)
@@ -90,7 +92,7 @@ users:
     password: admin-password
 `)
 
-		config, err := getRestConfig(validKubeconfig)
+		config, _, err := getRestConfig(validKubeconfig, &clientcmd.ConfigOverrides{})
 
 		require.NoError(t, err)
 		assert.NotNil(t, config)
@@ -180,3 +182,132 @@ users:
 		mockLoader.AssertExpectations(t)
 	})
 }
+
+// multiContextKubeconfig describes two clusters reachable through separate contexts.
+var multiContextKubeconfig = []byte(`
+apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://prod.example.com:6443
+  name: prod
+- cluster:
+    server: https://staging.example.com:6443
+  name: staging
+contexts:
+- context:
+    cluster: prod
+    namespace: audit
+    user: auditor
+  name: prod
+- context:
+    cluster: staging
+    user: auditor
+  name: staging
+current-context: prod
+users:
+- name: auditor
+  user:
+    token: auditor-token
+- name: admin
+  user:
+    token: admin-token
+`)
+
+func TestGetRestConfig_Overrides(t *testing.T) {
+	tests := []struct {
+		name          string
+		overrides     clientcmd.ConfigOverrides
+		wantHost      string
+		wantNamespace string
+		wantToken     string
+		errorContains string
+	}{
+		{
+			name:          "Current context",
+			wantHost:      "https://prod.example.com:6443",
+			wantNamespace: "audit",
+			wantToken:     "auditor-token",
+		},
+		{
+			name:          "Named context without namespace",
+			overrides:     clientcmd.ConfigOverrides{CurrentContext: "staging"},
+			wantHost:      "https://staging.example.com:6443",
+			wantNamespace: "default",
+			wantToken:     "auditor-token",
+		},
+		{
+			name: "Server, user and namespace overrides",
+			overrides: clientcmd.ConfigOverrides{
+				ClusterInfo: clientcmdapi.Cluster{Server: "https://tunnel.example.com"},
+				Context:     clientcmdapi.Context{AuthInfo: "admin", Namespace: "kube-system"},
+			},
+			wantHost:      "https://tunnel.example.com",
+			wantNamespace: "kube-system",
+			wantToken:     "admin-token",
+		},
+		{
+			name:          "Unknown context",
+			overrides:     clientcmd.ConfigOverrides{CurrentContext: "missing"},
+			errorContains: "clientCfg.ClientConfig failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, namespace, err := getRestConfig(multiContextKubeconfig, &tt.overrides)
+
+			if tt.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, config)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantHost, config.Host)
+			assert.Equal(t, tt.wantNamespace, namespace)
+			assert.Equal(t, tt.wantToken, config.BearerToken)
+		})
+	}
+}
+
+func TestKubeConfigConnection_Config(t *testing.T) {
+	mockLoader := &mocksauth.MockK8sAuthLoader{}
+	mockLoader.On("Load").Return(multiContextKubeconfig, nil).Once()
+
+	conn := NewKubeConfigConnection(mockLoader,
+		WithOverrides(clientcmd.ConfigOverrides{CurrentContext: "staging"}))
+
+	config, namespace, err := conn.Config()
+
+	require.NoError(t, err)
+	assert.Equal(t, "https://staging.example.com:6443", config.Host)
+	assert.Equal(t, "default", namespace)
+	mockLoader.AssertExpectations(t)
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Run("lists contexts", func(t *testing.T) {
+		mockLoader := &mocksauth.MockK8sAuthLoader{}
+		mockLoader.On("Load").Return(multiContextKubeconfig, nil).Once()
+
+		cfg, err := LoadConfig(mockLoader)
+
+		require.NoError(t, err)
+		assert.Equal(t, "prod", cfg.CurrentContext)
+		assert.Len(t, cfg.Contexts, 2)
+		mockLoader.AssertExpectations(t)
+	})
+
+	t.Run("invalid kubeconfig", func(t *testing.T) {
+		mockLoader := &mocksauth.MockK8sAuthLoader{}
+		mockLoader.On("Load").Return([]byte(`invalid yaml`), nil).Once()
+
+		cfg, err := LoadConfig(mockLoader)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "clientcmd.Load failed")
+		assert.Nil(t, cfg)
+	})
+}
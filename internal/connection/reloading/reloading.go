@@ -0,0 +1,327 @@
+// Package reloading keeps the credentials of a connection current while it is in use.
+//
+// A Reloader is an http.RoundTripper placed under all clients of a connection. It forwards
+// requests to a transport built from the latest *rest.Config and swaps that transport
+// atomically when the credentials change, either because a periodic reload observed new
+// credentials or because the API server rejected a request with 401 Unauthorized.
+package reloading
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/client-go/rest"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// DefaultMinInterval is the minimum time between two reloads triggered by 401 responses,
+// so a revoked credential does not cause a reload for every request.
+const DefaultMinInterval = 10 * time.Second
+
+var (
+	errLoad          = errors.New("reload failed")
+	errServerChanged = errors.New("server changed")
+	errTransport     = errors.New("transport rebuild failed")
+)
+
+// Reloader is an http.RoundTripper that sends requests with the latest credentials of
+// a connection. It is safe for concurrent use.
+type Reloader struct {
+	reload      func() (*rest.Config, error)
+	logger      *slog.Logger
+	host        string
+	minInterval time.Duration
+
+	current atomic.Pointer[state]
+
+	mu         sync.Mutex // serializes reloads
+	lastReload time.Time
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+	done     chan struct{}
+}
+
+// state is a transport together with the credentials it was built from.
+type state struct {
+	transport   http.RoundTripper
+	credentials credentials
+}
+
+// credentials are the fields of a *rest.Config that change on credential rotation.
+type credentials struct {
+	tls             rest.TLSClientConfig
+	bearerToken     string
+	bearerTokenFile string
+	username        string
+	password        string
+	impersonate     rest.ImpersonationConfig
+	authProvider    *clientcmdapi.AuthProviderConfig
+	execProvider    *clientcmdapi.ExecConfig
+}
+
+var _ http.RoundTripper = (*Reloader)(nil)
+
+// Option configures a Reloader.
+type Option func(*Reloader)
+
+// WithLogger sets the logger receiving failed reloads. It defaults to slog.Default.
+// Only the reason of a failure is logged, never its error, which may quote credentials.
+func WithLogger(logger *slog.Logger) Option {
+	return func(r *Reloader) {
+		r.logger = logger
+	}
+}
+
+// New returns a Reloader sending requests with the credentials of cfg until reload
+// returns different ones. The server of the connection must not change between reloads.
+//
+// Returns an error if no transport can be built from cfg.
+func New(cfg *rest.Config, reload func() (*rest.Config, error), opts ...Option) (*Reloader, error) {
+	r := &Reloader{
+		reload:      reload,
+		logger:      slog.Default(),
+		host:        cfg.Host,
+		minInterval: DefaultMinInterval,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	s, err := newState(cfg)
+	if err != nil {
+		return nil, err
+	}
+	r.current.Store(s)
+
+	return r, nil
+}
+
+// ClientConfig returns a copy of cfg that sends all requests through the reloader.
+// Credentials, TLS and transport settings are dropped from the copy as the transport of
+// the reloader applies them; request settings such as rate limits and timeouts are kept.
+func (r *Reloader) ClientConfig(cfg *rest.Config) *rest.Config {
+	out := rest.CopyConfig(cfg)
+
+	out.TLSClientConfig = rest.TLSClientConfig{}
+	out.BearerToken = ""
+	out.BearerTokenFile = ""
+	out.Username = ""
+	out.Password = ""
+	out.Impersonate = rest.ImpersonationConfig{}
+	out.AuthProvider = nil
+	out.AuthConfigPersister = nil
+	out.ExecProvider = nil
+	out.WrapTransport = nil
+	out.Proxy = nil
+	out.Dial = nil
+	out.Transport = r
+
+	return out
+}
+
+// Impersonates reports whether the current credentials impersonate another identity.
+// Clients of the reloader cannot replace that impersonation, as their own impersonation
+// headers would be combined with it.
+func (r *Reloader) Impersonates() bool {
+	impersonate := r.current.Load().credentials.impersonate
+
+	return impersonate.UserName != "" || impersonate.UID != "" || len(impersonate.Groups) > 0
+}
+
+// RoundTrip sends req with the current credentials. When the API server answers 401, the
+// credentials are reloaded and, if they changed, the request is sent once more with the
+// new ones. Requests whose body cannot be replayed are not retried.
+func (r *Reloader) RoundTrip(req *http.Request) (*http.Response, error) {
+	current := r.current.Load()
+
+	resp, err := current.transport.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || !replayable(req) {
+		return resp, err
+	}
+
+	next, reloadErr := r.reloadAfterUnauthorized(current)
+	if reloadErr != nil {
+		r.logger.Warn("failed to reload kubernetes credentials after 401 response",
+			"reason", reason(reloadErr))
+		return resp, nil
+	}
+	if next == current {
+		return resp, nil
+	}
+
+	retry := rewind(req)
+	if retry == nil {
+		return resp, nil
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	return next.transport.RoundTrip(retry)
+}
+
+// Reload loads the credentials of the connection and swaps the transport if they changed.
+// It returns an error if loading fails or the server of the connection changed.
+func (r *Reloader) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, err := r.reloadLocked()
+
+	return err
+}
+
+// Start reloads the credentials every interval until Stop is called.
+// Failed reloads are logged and keep the current credentials. An interval of zero
+// disables periodic reloads; 401 responses still trigger them.
+func (r *Reloader) Start(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	r.stopCh = make(chan struct{})
+	r.done = make(chan struct{})
+
+	go func() {
+		defer close(r.done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.stopCh:
+				return
+			case <-ticker.C:
+				if err := r.Reload(); err != nil {
+					r.logger.Warn("failed to reload kubernetes credentials", "reason", reason(err))
+				}
+			}
+		}
+	}()
+}
+
+// Stop stops periodic reloads and waits for a running reload to finish.
+// It is safe to call Stop multiple times and without Start.
+func (r *Reloader) Stop() {
+	r.stopOnce.Do(func() {
+		if r.stopCh == nil {
+			return
+		}
+
+		close(r.stopCh)
+		<-r.done
+	})
+}
+
+// reloadAfterUnauthorized reloads the credentials after stale was rejected, unless another
+// request already replaced stale or the last reload is too recent.
+func (r *Reloader) reloadAfterUnauthorized(stale *state) (*state, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if current := r.current.Load(); current != stale {
+		return current, nil
+	}
+	if time.Since(r.lastReload) < r.minInterval {
+		return stale, nil
+	}
+
+	return r.reloadLocked()
+}
+
+// reloadLocked loads the credentials and swaps the transport if they changed.
+// It returns the state in use afterwards. r.mu must be held.
+func (r *Reloader) reloadLocked() (*state, error) {
+	current := r.current.Load()
+	r.lastReload = time.Now()
+
+	cfg, err := r.reload()
+	if err != nil {
+		return current, fmt.Errorf("%w: %w", errLoad, err)
+	}
+
+	if cfg.Host != r.host {
+		return current, fmt.Errorf("%w from %q to %q: a new client is required", errServerChanged, r.host, cfg.Host)
+	}
+
+	if reflect.DeepEqual(credentialsOf(cfg), current.credentials) {
+		return current, nil
+	}
+
+	next, err := newState(cfg)
+	if err != nil {
+		return current, fmt.Errorf("%w: %w", errTransport, err)
+	}
+	r.current.Store(next)
+
+	return next, nil
+}
+
+// reason returns a short description of a failed reload for logs, such as "load_failed".
+// Errors of loaders may quote kubeconfig contents or tokens, so their text is not logged.
+func reason(err error) string {
+	switch {
+	case errors.Is(err, errLoad):
+		return "load_failed"
+	case errors.Is(err, errServerChanged):
+		return "server_changed"
+	case errors.Is(err, errTransport):
+		return "transport_failed"
+	default:
+		return "unknown"
+	}
+}
+
+// newState builds the transport of cfg.
+func newState(cfg *rest.Config) (*state, error) {
+	transport, err := rest.TransportFor(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("rest.TransportFor failed: %w", err)
+	}
+
+	return &state{transport: transport, credentials: credentialsOf(cfg)}, nil
+}
+
+// credentialsOf returns the credentials of cfg.
+func credentialsOf(cfg *rest.Config) credentials {
+	return credentials{
+		tls:             cfg.TLSClientConfig,
+		bearerToken:     cfg.BearerToken,
+		bearerTokenFile: cfg.BearerTokenFile,
+		username:        cfg.Username,
+		password:        cfg.Password,
+		impersonate:     cfg.Impersonate,
+		authProvider:    cfg.AuthProvider,
+		execProvider:    cfg.ExecProvider,
+	}
+}
+
+// replayable reports whether req can be sent again.
+func replayable(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// rewind returns a copy of req with a fresh body, or nil if the body cannot be recreated.
+func rewind(req *http.Request) *http.Request {
+	retry := req.Clone(req.Context())
+	if req.Body == nil || req.Body == http.NoBody {
+		return retry
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil
+	}
+	retry.Body = body
+
+	return retry
+}
@@ -0,0 +1,280 @@
+package reloading
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// tokenServer is an API server accepting a single bearer token that can be rotated.
+type tokenServer struct {
+	*httptest.Server
+
+	mu           sync.Mutex
+	token        string
+	unauthorized int
+}
+
+func newTokenServer(t *testing.T, token string) *tokenServer {
+	t.Helper()
+
+	s := &tokenServer{token: token}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		ok := r.Header.Get("Authorization") == "Bearer "+s.token
+		if !ok {
+			s.unauthorized++
+		}
+		s.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Unauthorized","code":401}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"kind":"Namespace","apiVersion":"v1","metadata":{"name":"default"}}`))
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (s *tokenServer) rotate(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.token = token
+}
+
+func (s *tokenServer) unauthorizedCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.unauthorized
+}
+
+// tokenSource returns configs for host carrying a token that can be rotated, and counts loads.
+type tokenSource struct {
+	host  string
+	token atomic.Value
+	loads atomic.Int32
+	fail  atomic.Bool
+}
+
+func newTokenSource(host, token string) *tokenSource {
+	s := &tokenSource{host: host}
+	s.token.Store(token)
+
+	return s
+}
+
+func (s *tokenSource) load() (*rest.Config, error) {
+	s.loads.Add(1)
+	if s.fail.Load() {
+		return nil, errors.New("kubeconfig unavailable")
+	}
+
+	return &rest.Config{Host: s.host, BearerToken: s.token.Load().(string)}, nil
+}
+
+func getNamespace(t *testing.T, r *Reloader, cfg *rest.Config) error {
+	t.Helper()
+
+	clientset, err := kubernetes.NewForConfig(r.ClientConfig(cfg))
+	require.NoError(t, err)
+
+	_, err = clientset.CoreV1().Namespaces().Get(context.Background(), "default", metav1.GetOptions{})
+
+	return err
+}
+
+func TestReloader_Unauthorized(t *testing.T) {
+	server := newTokenServer(t, "first")
+	source := newTokenSource(server.URL, "first")
+
+	cfg, err := source.load()
+	require.NoError(t, err)
+
+	r, err := New(cfg, source.load)
+	require.NoError(t, err)
+	r.minInterval = 0
+
+	t.Run("uses initial credentials", func(t *testing.T) {
+		require.NoError(t, getNamespace(t, r, cfg))
+		assert.Equal(t, int32(1), source.loads.Load())
+	})
+
+	t.Run("reloads and retries after rotation", func(t *testing.T) {
+		server.rotate("second")
+		source.token.Store("second")
+
+		require.NoError(t, getNamespace(t, r, cfg))
+		assert.Equal(t, int32(2), source.loads.Load())
+		assert.Equal(t, 1, server.unauthorizedCount())
+
+		require.NoError(t, getNamespace(t, r, cfg))
+		assert.Equal(t, int32(2), source.loads.Load())
+	})
+
+	t.Run("returns 401 when credentials did not change", func(t *testing.T) {
+		server.rotate("third")
+
+		err := getNamespace(t, r, cfg)
+		assert.True(t, apierrors.IsUnauthorized(err))
+		assert.Equal(t, int32(3), source.loads.Load())
+	})
+
+	t.Run("limits reloads after 401", func(t *testing.T) {
+		r.minInterval = time.Hour
+		source.token.Store("third")
+
+		err := getNamespace(t, r, cfg)
+		assert.True(t, apierrors.IsUnauthorized(err))
+		assert.Equal(t, int32(3), source.loads.Load())
+	})
+
+	t.Run("returns 401 when reload fails", func(t *testing.T) {
+		var buf bytes.Buffer
+		r.logger = slog.New(slog.NewTextHandler(&buf, nil))
+		r.minInterval = 0
+		source.fail.Store(true)
+		defer source.fail.Store(false)
+
+		err := getNamespace(t, r, cfg)
+		assert.True(t, apierrors.IsUnauthorized(err))
+		assert.Contains(t, buf.String(), "reason=load_failed")
+		assert.NotContains(t, buf.String(), "kubeconfig unavailable")
+	})
+}
+
+func TestReloader_Start(t *testing.T) {
+	server := newTokenServer(t, "first")
+	source := newTokenSource(server.URL, "first")
+
+	cfg, err := source.load()
+	require.NoError(t, err)
+
+	r, err := New(cfg, source.load)
+	require.NoError(t, err)
+	r.minInterval = time.Hour
+
+	r.Start(10 * time.Millisecond)
+	defer r.Stop()
+
+	server.rotate("second")
+	source.token.Store("second")
+
+	assert.Eventually(t, func() bool {
+		return r.current.Load().credentials.bearerToken == "second"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, getNamespace(t, r, cfg))
+	assert.Zero(t, server.unauthorizedCount())
+
+	r.Stop()
+	loads := source.loads.Load()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, loads, source.loads.Load())
+}
+
+func TestReloader_Reload(t *testing.T) {
+	source := newTokenSource("https://cluster.example.com", "token")
+
+	cfg, err := source.load()
+	require.NoError(t, err)
+
+	r, err := New(cfg, source.load)
+	require.NoError(t, err)
+
+	t.Run("keeps transport when credentials are unchanged", func(t *testing.T) {
+		current := r.current.Load()
+
+		require.NoError(t, r.Reload())
+		assert.Same(t, current, r.current.Load())
+	})
+
+	t.Run("rejects server change", func(t *testing.T) {
+		source.host = "https://other.example.com"
+		defer func() { source.host = "https://cluster.example.com" }()
+
+		err := r.Reload()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "server changed")
+	})
+
+	t.Run("stop without start", func(t *testing.T) {
+		r.Stop()
+		r.Stop()
+	})
+}
+
+func TestReloader_ClientConfig(t *testing.T) {
+	cfg := &rest.Config{
+		Host:            "https://cluster.example.com",
+		BearerToken:     "token",
+		TLSClientConfig: rest.TLSClientConfig{Insecure: true},
+		QPS:             50,
+		Timeout:         time.Minute,
+	}
+
+	r, err := New(cfg, func() (*rest.Config, error) { return cfg, nil })
+	require.NoError(t, err)
+
+	out := r.ClientConfig(cfg)
+
+	assert.Same(t, r, out.Transport)
+	assert.Empty(t, out.BearerToken)
+	assert.False(t, out.Insecure)
+	assert.Equal(t, float32(50), out.QPS)
+	assert.Equal(t, time.Minute, out.Timeout)
+	assert.Equal(t, "token", cfg.BearerToken)
+}
+
+func TestReloader_Impersonates(t *testing.T) {
+	cfg := &rest.Config{Host: "https://cluster.example.com", BearerToken: "token"}
+
+	r, err := New(cfg, func() (*rest.Config, error) { return cfg, nil })
+	require.NoError(t, err)
+	assert.False(t, r.Impersonates())
+
+	cfg = rest.CopyConfig(cfg)
+	cfg.Impersonate = rest.ImpersonationConfig{UserName: "jane"}
+
+	r, err = New(cfg, func() (*rest.Config, error) { return cfg, nil })
+	require.NoError(t, err)
+	assert.True(t, r.Impersonates())
+}
+
+func TestRewind(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "https://cluster.example.com", strings.NewReader("body"))
+	require.NoError(t, err)
+	require.True(t, replayable(req))
+
+	retry := rewind(req)
+	require.NotNil(t, retry)
+
+	body, err := io.ReadAll(retry.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "body", string(body))
+
+	req.GetBody = nil
+	assert.False(t, replayable(req))
+}
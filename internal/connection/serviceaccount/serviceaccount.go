@@ -1,16 +1,79 @@
 package serviceaccount
 
 import (
+	"errors"
 	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	certutil "k8s.io/client-go/util/cert"
 )
 
-func ServiceAccountConnectionNativeAPI() (kubernetes.Interface, error) {
-	config, err := rest.InClusterConfig()
+// DefaultDir is the directory the service account token, CA certificate and namespace
+// are mounted to in every pod.
+const DefaultDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+// Names of the files in the service account directory besides the token.
+const (
+	caFileName        = "ca.crt"
+	namespaceFileName = "namespace"
+)
+
+// Environment variables set by the kubelet in every pod to locate the API server.
+const (
+	hostEnv = "KUBERNETES_SERVICE_HOST"
+	portEnv = "KUBERNETES_SERVICE_PORT"
+)
+
+// ServiceAccountConnection connects to the API server with the service account mounted into
+// the pod. Unlike rest.InClusterConfig, the mount directory and the API server address can be
+// configured, e.g. for projected tokens mounted elsewhere or for tests.
+type ServiceAccountConnection struct {
+	dir  string
+	host string
+	port string
+}
+
+// Option configures a ServiceAccountConnection.
+type Option func(*ServiceAccountConnection)
+
+// WithDir reads the token, ca.crt and namespace files from dir instead of DefaultDir.
+func WithDir(dir string) Option {
+	return func(s *ServiceAccountConnection) {
+		s.dir = dir
+	}
+}
+
+// WithAPIServer connects to host and port instead of the address in the
+// KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT environment variables.
+func WithAPIServer(host, port string) Option {
+	return func(s *ServiceAccountConnection) {
+		s.host = host
+		s.port = port
+	}
+}
+
+// NewServiceAccountConnection returns a ServiceAccountConnection reading DefaultDir and the
+// API server environment variables unless configured otherwise.
+func NewServiceAccountConnection(opts ...Option) *ServiceAccountConnection {
+	s := &ServiceAccountConnection{dir: DefaultDir}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// NativeAPI returns a typed Kubernetes client authenticated as the service account.
+// It returns an error if building the configuration or creating the client fails.
+func (s *ServiceAccountConnection) NativeAPI() (kubernetes.Interface, error) {
+	config, err := s.RestConfig()
 	if err != nil {
-		return nil, fmt.Errorf("rest.InClusterConfig failed: %w", err)
+		return nil, err
 	}
 
 	clientset, err := kubernetes.NewForConfig(config)
@@ -20,3 +83,82 @@ func ServiceAccountConnectionNativeAPI() (kubernetes.Interface, error) {
 
 	return clientset, nil
 }
+
+// RestConfig returns a *rest.Config authenticated with the service account token, which is
+// re-read periodically so rotated projected tokens are picked up.
+// It returns rest.ErrNotInCluster if the API server address is unknown, and an error if the
+// token or CA certificate cannot be read.
+func (s *ServiceAccountConnection) RestConfig() (*rest.Config, error) {
+	host, port := s.apiServer()
+	if host == "" || port == "" {
+		return nil, rest.ErrNotInCluster
+	}
+
+	tokenFile := filepath.Join(s.dir, "token")
+	token, err := os.ReadFile(tokenFile)
+	if err != nil {
+		return nil, fmt.Errorf("os.ReadFile failed: %w", err)
+	}
+
+	caFile := filepath.Join(s.dir, caFileName)
+	if _, err = certutil.NewPool(caFile); err != nil {
+		return nil, fmt.Errorf("certutil.NewPool failed: %w", err)
+	}
+
+	return &rest.Config{
+		Host:            "https://" + net.JoinHostPort(host, port),
+		TLSClientConfig: rest.TLSClientConfig{CAFile: caFile},
+		BearerToken:     string(token),
+		BearerTokenFile: tokenFile,
+	}, nil
+}
+
+// Namespace returns the namespace of the pod from the mounted namespace file.
+// It returns an error if the file cannot be read or is empty.
+func (s *ServiceAccountConnection) Namespace() (string, error) {
+	b, err := os.ReadFile(filepath.Join(s.dir, namespaceFileName))
+	if err != nil {
+		return "", fmt.Errorf("os.ReadFile failed: %w", err)
+	}
+
+	namespace := strings.TrimSpace(string(b))
+	if namespace == "" {
+		return "", errors.New("namespace file is empty")
+	}
+
+	return namespace, nil
+}
+
+// InCluster reports whether the service account is available, that is the API server
+// address is known and a token is mounted.
+func (s *ServiceAccountConnection) InCluster() bool {
+	if host, port := s.apiServer(); host == "" || port == "" {
+		return false
+	}
+
+	_, err := os.Stat(filepath.Join(s.dir, "token"))
+
+	return err == nil
+}
+
+// apiServer returns the configured API server address, falling back to the environment.
+func (s *ServiceAccountConnection) apiServer() (string, string) {
+	if s.host != "" {
+		return s.host, s.port
+	}
+
+	return os.Getenv(hostEnv), os.Getenv(portEnv)
+}
+
+// ServiceAccountConnectionNativeAPI returns a Kubernetes clientset for the service account
+// of the pod the process runs in. It is kept for compatibility and is equivalent to
+// NewServiceAccountConnection().NativeAPI().
+func ServiceAccountConnectionNativeAPI() (kubernetes.Interface, error) {
+	return NewServiceAccountConnection().NativeAPI()
+}
+
+// InCluster reports whether the process runs inside a Kubernetes pod, that is the API server
+// environment variables are set and a service account token is mounted at DefaultDir.
+func InCluster() bool {
+	return NewServiceAccountConnection().InCluster()
+}
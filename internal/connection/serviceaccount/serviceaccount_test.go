@@ -2,11 +2,15 @@ package serviceaccount
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	certutil "k8s.io/client-go/util/cert"
 )
 
 // TestServiceAccountConnectionNativeAPI tests the ServiceAccountConnectionNativeAPI function
@@ -98,3 +102,160 @@ func createServiceAccountConnectionFunc(
 		return clientset, nil
 	}
 }
+
+func TestInCluster(t *testing.T) {
+	t.Run("false without API server environment", func(t *testing.T) {
+		t.Setenv("KUBERNETES_SERVICE_HOST", "")
+		t.Setenv("KUBERNETES_SERVICE_PORT", "443")
+
+		assert.False(t, InCluster())
+	})
+
+	t.Run("false without mounted token", func(t *testing.T) {
+		if _, err := os.Stat(filepath.Join(DefaultDir, "token")); err == nil {
+			t.Skip("service account token is mounted")
+		}
+
+		t.Setenv("KUBERNETES_SERVICE_HOST", "10.0.0.1")
+		t.Setenv("KUBERNETES_SERVICE_PORT", "443")
+
+		assert.False(t, InCluster())
+	})
+}
+
+// writeServiceAccount writes a service account mount with the given files to a temp dir.
+func writeServiceAccount(t *testing.T, files map[string][]byte) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), content, 0o600))
+	}
+
+	return dir
+}
+
+func TestServiceAccountConnection(t *testing.T) {
+	caPEM, _, err := certutil.GenerateSelfSignedCertKey("kubernetes.default.svc", nil, nil)
+	require.NoError(t, err)
+
+	valid := writeServiceAccount(t, map[string][]byte{
+		"token":     []byte("sa-token"),
+		"ca.crt":    caPEM,
+		"namespace": []byte("audit\n"),
+	})
+
+	tests := []struct {
+		name          string
+		env           map[string]string
+		opts          []Option
+		wantHost      string
+		wantErrIs     error
+		errorContains string
+	}{
+		{
+			name:     "Configured directory and API server",
+			opts:     []Option{WithDir(valid), WithAPIServer("10.0.0.1", "6443")},
+			wantHost: "https://10.0.0.1:6443",
+		},
+		{
+			name:     "API server from environment",
+			env:      map[string]string{"KUBERNETES_SERVICE_HOST": "fd00::1", "KUBERNETES_SERVICE_PORT": "443"},
+			opts:     []Option{WithDir(valid)},
+			wantHost: "https://[fd00::1]:443",
+		},
+		{
+			name:      "Not in cluster",
+			env:       map[string]string{"KUBERNETES_SERVICE_HOST": "", "KUBERNETES_SERVICE_PORT": ""},
+			opts:      []Option{WithDir(valid)},
+			wantErrIs: rest.ErrNotInCluster,
+		},
+		{
+			name:          "Missing token",
+			opts:          []Option{WithDir(t.TempDir()), WithAPIServer("10.0.0.1", "6443")},
+			errorContains: "os.ReadFile failed",
+		},
+		{
+			name: "Invalid CA certificate",
+			opts: []Option{
+				WithDir(writeServiceAccount(t, map[string][]byte{"token": []byte("sa-token"), "ca.crt": []byte("x")})),
+				WithAPIServer("10.0.0.1", "6443"),
+			},
+			errorContains: "certutil.NewPool failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			cfg, err := NewServiceAccountConnection(tt.opts...).RestConfig()
+
+			if tt.wantErrIs != nil || tt.errorContains != "" {
+				require.Error(t, err)
+				if tt.wantErrIs != nil {
+					assert.ErrorIs(t, err, tt.wantErrIs)
+				}
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, cfg)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantHost, cfg.Host)
+			assert.Equal(t, "sa-token", cfg.BearerToken)
+			assert.Equal(t, filepath.Join(valid, "token"), cfg.BearerTokenFile)
+			assert.Equal(t, filepath.Join(valid, "ca.crt"), cfg.CAFile)
+		})
+	}
+}
+
+func TestServiceAccountConnection_Namespace(t *testing.T) {
+	tests := []struct {
+		name          string
+		files         map[string][]byte
+		want          string
+		errorContains string
+	}{
+		{
+			name:  "Reads pod namespace",
+			files: map[string][]byte{"namespace": []byte("audit\n")},
+			want:  "audit",
+		},
+		{
+			name:          "Missing namespace file",
+			errorContains: "os.ReadFile failed",
+		},
+		{
+			name:          "Empty namespace file",
+			files:         map[string][]byte{"namespace": []byte("\n")},
+			errorContains: "namespace file is empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := NewServiceAccountConnection(WithDir(writeServiceAccount(t, tt.files)))
+
+			namespace, err := conn.Namespace()
+
+			if tt.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, namespace)
+		})
+	}
+}
+
+func TestServiceAccountConnection_InCluster(t *testing.T) {
+	dir := writeServiceAccount(t, map[string][]byte{"token": []byte("sa-token")})
+
+	assert.True(t, NewServiceAccountConnection(WithDir(dir), WithAPIServer("10.0.0.1", "443")).InCluster())
+	assert.False(t, NewServiceAccountConnection(WithDir(t.TempDir()), WithAPIServer("10.0.0.1", "443")).InCluster())
+}
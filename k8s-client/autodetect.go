@@ -0,0 +1,48 @@
+package k8sclient
+
+import (
+	"github.com/kaudit/k8s_client/internal/connection/serviceaccount"
+	kubeconfigloader "github.com/kaudit/k8s_client/loader/kubeconfig"
+)
+
+// ConnectionSource identifies where the connection settings of a K8sClient came from.
+type ConnectionSource string
+
+const (
+	// ConnectionSourceServiceAccount is the in-cluster service account of the pod.
+	ConnectionSourceServiceAccount ConnectionSource = "service-account"
+	// ConnectionSourceKubeconfig is a kubeconfig.
+	ConnectionSourceKubeconfig ConnectionSource = "kubeconfig"
+	// ConnectionSourceToken is a bearer token connection configured with WithTokenAuth.
+	ConnectionSourceToken ConnectionSource = "token"
+)
+
+// WithAutoDetect creates a K8sClientOption that selects the connection from the environment,
+// so the same binary runs on a workstation and inside a cluster.
+//
+// When the KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT environment variables are set
+// and a service account token is mounted, the client connects as with WithServiceAccount.
+// Otherwise it connects as with WithKubeConfigLoader, merging the files of the KUBECONFIG
+// environment variable or reading ~/.kube/config like kubectl does.
+// ConnectionSource reports the choice once the client is built.
+//
+// This option is mutually exclusive with WithKubeConfigLoader, WithServiceAccount and
+// WithTokenAuth. Applying more than one connection option to the same client results in
+// ErrAlreadyConfigured.
+func WithAutoDetect() K8sClientOption {
+	return func(k8sClient *K8sClient) error {
+		return k8sClient.setConnector(func() (*connection, error) {
+			if serviceaccount.InCluster() {
+				return k8sClient.connectServiceAccount()
+			}
+
+			return k8sClient.connectKubeConfig(kubeconfigloader.NewMergedConfigLoader())
+		})
+	}
+}
+
+// ConnectionSource returns where the connection settings of the client came from.
+// It is empty for clients not built by NewK8sClient.
+func (k *K8sClient) ConnectionSource() ConnectionSource {
+	return k.source
+}
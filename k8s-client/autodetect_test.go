@@ -0,0 +1,70 @@
+package k8sclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+func TestWithAutoDetect(t *testing.T) {
+	var authorization string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"kind":"Pod","apiVersion":"v1","metadata":{"name":"web","namespace":"audit"}}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(path, testKubeconfig(server.URL), 0o600))
+
+	t.Run("falls back to KUBECONFIG outside a cluster", func(t *testing.T) {
+		t.Setenv("KUBERNETES_SERVICE_HOST", "")
+		t.Setenv(clientcmd.RecommendedConfigPathEnvVar, path)
+
+		client, err := NewK8sClient(WithAutoDetect(), WithContext("test"))
+		require.NoError(t, err)
+		defer client.Close()
+
+		assert.Equal(t, ConnectionSourceKubeconfig, client.ConnectionSource())
+		assert.Equal(t, "audit", client.DefaultNamespace())
+
+		_, err = client.GetPodAPI().GetPodByName(context.Background(), "audit", "web")
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer auditor-token", authorization)
+	})
+
+	t.Run("fails without any kubeconfig", func(t *testing.T) {
+		t.Setenv("KUBERNETES_SERVICE_HOST", "")
+		t.Setenv(clientcmd.RecommendedConfigPathEnvVar, filepath.Join(t.TempDir(), "missing"))
+
+		client, err := NewK8sClient(WithAutoDetect())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no kubeconfig found")
+		assert.Nil(t, client)
+	})
+
+	t.Run("conflicts with other connection options", func(t *testing.T) {
+		client, err := NewK8sClient(WithAutoDetect(), WithServiceAccount())
+
+		require.ErrorIs(t, err, ErrAlreadyConfigured)
+		assert.Nil(t, client)
+	})
+
+	t.Run("reports explicit kubeconfig connection", func(t *testing.T) {
+		client, err := NewK8sClient(WithKubeConfigLoader(newMockLoader(testKubeconfig(server.URL))),
+			WithContext("test"))
+		require.NoError(t, err)
+		defer client.Close()
+
+		assert.Equal(t, ConnectionSourceKubeconfig, client.ConnectionSource())
+	})
+}
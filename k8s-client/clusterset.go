@@ -0,0 +1,222 @@
+package k8sclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/kaudit/val"
+
+	api "github.com/kaudit/k8s_client"
+	kubeconfigloader "github.com/kaudit/k8s_client/loader/kubeconfig"
+)
+
+// ClusterConfig names a cluster of a ClusterSet and lists the options building its client.
+// Options must include exactly one connection option such as WithKubeConfigLoader.
+type ClusterConfig struct {
+	Name    string `validate:"required"`
+	Options []K8sClientOption
+}
+
+// ClusterResult is the outcome of a function run against a single cluster of a ClusterSet.
+type ClusterResult[T any] struct {
+	Cluster string
+	Value   T
+	Err     error
+}
+
+// ClusterSet holds one K8sClient per cluster and runs functions against all of them concurrently.
+//
+// A cluster whose client could not be built stays part of the set; every run reports the build
+// error as the result of that cluster, so one unreachable cluster does not hide the others.
+type ClusterSet struct {
+	clusters    []cluster
+	concurrency int
+}
+
+// cluster is a member of a ClusterSet: either a client or the error that prevented building it.
+type cluster struct {
+	name   string
+	client *K8sClient
+	err    error
+}
+
+// NewClusterSet builds a client for each of the given clusters.
+//
+// Parameters:
+//   - clusters: Uniquely named clusters with the options of their clients.
+//   - concurrency: Maximum number of clusters a run works on at the same time (must be at least 1).
+//
+// Returns the set in the order of clusters, or an error if the input is invalid.
+// Failures to build individual clients are reported by Run, not here.
+func NewClusterSet(clusters []ClusterConfig, concurrency int) (*ClusterSet, error) {
+	if err := val.ValidateWithTag(clusters, "required,min=1,dive"); err != nil {
+		return nil, fmt.Errorf("invalid clusters: %w", err)
+	}
+	if err := val.ValidateWithTag(concurrency, "gte=1"); err != nil {
+		return nil, fmt.Errorf("invalid concurrency: %w", err)
+	}
+
+	set := &ClusterSet{concurrency: concurrency}
+	seen := make(map[string]struct{}, len(clusters))
+
+	for _, cfg := range clusters {
+		if _, ok := seen[cfg.Name]; ok {
+			set.Close()
+			return nil, fmt.Errorf("duplicate cluster name %q", cfg.Name)
+		}
+		seen[cfg.Name] = struct{}{}
+
+		client, err := NewK8sClient(cfg.Options...)
+		set.clusters = append(set.clusters, cluster{name: cfg.Name, client: client, err: err})
+	}
+
+	return set, nil
+}
+
+// NewClusterSetFromKubeconfig builds a ClusterSet with one cluster per context of the
+// kubeconfig provided by loader, named after the context. The kubeconfig is loaded once and
+// shared by all clients, so loaders that decrypt or fetch it are not called per cluster;
+// credential reloads of the clients reuse that copy.
+//
+// Parameters:
+//   - loader: Loader of a kubeconfig with one context per cluster.
+//   - concurrency: Maximum number of clusters a run works on at the same time (must be at least 1).
+//   - options: Options applied to every client in addition to the context selection.
+//
+// Returns the set ordered by context name, or an error if the kubeconfig cannot be loaded.
+func NewClusterSetFromKubeconfig(loader api.K8sAuthLoader, concurrency int,
+	options ...K8sClientOption) (*ClusterSet, error) {
+
+	data, err := loader.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	shared := kubeconfigloader.NewBytesLoader(data)
+
+	contexts, err := ListContexts(shared)
+	if err != nil {
+		return nil, err
+	}
+
+	clusters := make([]ClusterConfig, 0, len(contexts))
+	for _, kubeContext := range contexts {
+		clusterOptions := append([]K8sClientOption{WithKubeConfigLoader(shared), WithContext(kubeContext.Name)},
+			options...)
+		clusters = append(clusters, ClusterConfig{Name: kubeContext.Name, Options: clusterOptions})
+	}
+
+	return NewClusterSet(clusters, concurrency)
+}
+
+// Clusters returns the names of the clusters in the set in order.
+func (s *ClusterSet) Clusters() []string {
+	names := make([]string, 0, len(s.clusters))
+	for _, c := range s.clusters {
+		names = append(names, c.name)
+	}
+
+	return names
+}
+
+// Client returns the client of the named cluster, or the error that prevented building it.
+func (s *ClusterSet) Client(name string) (*K8sClient, error) {
+	for _, c := range s.clusters {
+		if c.name == name {
+			return c.client, c.err
+		}
+	}
+
+	return nil, fmt.Errorf("unknown cluster %q", name)
+}
+
+// Close closes the clients of all clusters.
+func (s *ClusterSet) Close() {
+	for _, c := range s.clusters {
+		if c.client != nil {
+			c.client.Close()
+		}
+	}
+}
+
+// Run calls fn for every cluster of set, working on at most the configured number of clusters
+// at the same time. Clusters whose client could not be built are not passed to fn; their result
+// carries the build error instead. A panic in fn is recovered and reported as that cluster's error.
+//
+// Returns one result per cluster in the order of the set. When ctx is done, clusters that were
+// not started yet report the context error.
+func Run[T any](ctx context.Context, set *ClusterSet,
+	fn func(ctx context.Context, cluster string, client *K8sClient) (T, error)) []ClusterResult[T] {
+
+	results := make([]ClusterResult[T], len(set.clusters))
+	sem := make(chan struct{}, set.concurrency)
+
+	var wg sync.WaitGroup
+	for i, c := range set.clusters {
+		results[i].Cluster = c.name
+
+		if c.err != nil {
+			results[i].Err = fmt.Errorf("failed to build client: %w", c.err)
+			continue
+		}
+
+		if !acquire(ctx, sem) {
+			results[i].Err = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func(result *ClusterResult[T], c cluster) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result.Value, result.Err = call(ctx, c, fn)
+		}(&results[i], c)
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// acquire takes a slot of sem, waiting until one is free.
+// It returns false without taking a slot once ctx is done.
+func acquire(ctx context.Context, sem chan struct{}) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	select {
+	case <-ctx.Done():
+		return false
+	case sem <- struct{}{}:
+		return true
+	}
+}
+
+// call runs fn for a single cluster and converts a panic into an error.
+func call[T any](ctx context.Context, c cluster,
+	fn func(ctx context.Context, cluster string, client *K8sClient) (T, error)) (value T, err error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic in cluster %q: %v", c.name, r)
+		}
+	}()
+
+	return fn(ctx, c.name, c.client)
+}
+
+// JoinErrors returns the errors of all failed results prefixed with their cluster name,
+// or nil if every cluster succeeded.
+func JoinErrors[T any](results []ClusterResult[T]) error {
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("cluster %q: %w", result.Cluster, result.Err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
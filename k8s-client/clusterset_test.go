@@ -0,0 +1,228 @@
+package k8sclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mocksauth "github.com/kaudit/k8s_client/mocks/K8sAuthLoader"
+)
+
+// concurrencyTracker records the highest number of concurrently running calls.
+type concurrencyTracker struct {
+	mu      sync.Mutex
+	running int
+	peak    int
+}
+
+func (c *concurrencyTracker) enter() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.running++
+	c.peak = max(c.peak, c.running)
+}
+
+func (c *concurrencyTracker) leave() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.running--
+}
+
+func TestNewClusterSet_Invalid(t *testing.T) {
+	tests := []struct {
+		name          string
+		clusters      []ClusterConfig
+		concurrency   int
+		errorContains string
+	}{
+		{
+			name:          "No clusters",
+			concurrency:   1,
+			errorContains: "invalid clusters",
+		},
+		{
+			name:          "Unnamed cluster",
+			clusters:      []ClusterConfig{{Options: []K8sClientOption{withTestServer("http://a.example.com")}}},
+			concurrency:   1,
+			errorContains: "invalid clusters",
+		},
+		{
+			name:          "Invalid concurrency",
+			clusters:      []ClusterConfig{{Name: "a", Options: []K8sClientOption{withTestServer("http://a.example.com")}}},
+			errorContains: "invalid concurrency",
+		},
+		{
+			name: "Duplicate cluster name",
+			clusters: []ClusterConfig{
+				{Name: "a", Options: []K8sClientOption{withTestServer("http://a.example.com")}},
+				{Name: "a", Options: []K8sClientOption{withTestServer("http://b.example.com")}},
+			},
+			concurrency:   1,
+			errorContains: `duplicate cluster name "a"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set, err := NewClusterSet(tt.clusters, tt.concurrency)
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+			assert.Nil(t, set)
+		})
+	}
+}
+
+func TestClusterSet_Run(t *testing.T) {
+	var userAgent string
+	server := httptest.NewServer(podHandler(&userAgent))
+	defer server.Close()
+
+	clusters := make([]ClusterConfig, 0, 6)
+	for i := range 5 {
+		clusters = append(clusters, ClusterConfig{
+			Name:    fmt.Sprintf("cluster-%d", i),
+			Options: []K8sClientOption{withTestServer(server.URL)},
+		})
+	}
+	clusters = append(clusters, ClusterConfig{Name: "unconfigured"})
+
+	set, err := NewClusterSet(clusters, 2)
+	require.NoError(t, err)
+	defer set.Close()
+
+	assert.Equal(t, []string{"cluster-0", "cluster-1", "cluster-2", "cluster-3", "cluster-4", "unconfigured"},
+		set.Clusters())
+
+	t.Run("aggregates results and errors per cluster", func(t *testing.T) {
+		tracker := &concurrencyTracker{}
+
+		results := Run(context.Background(), set, func(ctx context.Context, cluster string, client *K8sClient) (string, error) {
+			tracker.enter()
+			defer tracker.leave()
+			time.Sleep(10 * time.Millisecond)
+
+			switch cluster {
+			case "cluster-1":
+				return "", fmt.Errorf("audit failed")
+			case "cluster-2":
+				panic("boom")
+			}
+
+			pod, err := client.GetPodAPI().GetPodByName(ctx, "default", "web")
+			if err != nil {
+				return "", err
+			}
+
+			return pod.Name, nil
+		})
+
+		require.Len(t, results, 6)
+		assert.LessOrEqual(t, tracker.peak, 2)
+
+		for _, i := range []int{0, 3, 4} {
+			assert.Equal(t, fmt.Sprintf("cluster-%d", i), results[i].Cluster)
+			assert.NoError(t, results[i].Err)
+			assert.Equal(t, "web", results[i].Value)
+		}
+
+		assert.EqualError(t, results[1].Err, "audit failed")
+		assert.ErrorContains(t, results[2].Err, `panic in cluster "cluster-2": boom`)
+		assert.ErrorIs(t, results[5].Err, ErrNotConfigured)
+
+		err := JoinErrors(results)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `cluster "cluster-1": audit failed`)
+		assert.Contains(t, err.Error(), `cluster "unconfigured": failed to build client`)
+		assert.NotContains(t, err.Error(), "cluster-0")
+	})
+
+	t.Run("skips remaining clusters when context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var calls atomic.Int32
+		results := Run(ctx, set, func(context.Context, string, *K8sClient) (struct{}, error) {
+			calls.Add(1)
+			return struct{}{}, nil
+		})
+
+		require.Len(t, results, 6)
+		for _, result := range results[:5] {
+			assert.ErrorIs(t, result.Err, context.Canceled)
+		}
+		assert.Zero(t, calls.Load())
+	})
+
+	t.Run("returns clients by name", func(t *testing.T) {
+		client, err := set.Client("cluster-0")
+		require.NoError(t, err)
+		assert.NotNil(t, client)
+
+		_, err = set.Client("unconfigured")
+		assert.ErrorIs(t, err, ErrNotConfigured)
+
+		_, err = set.Client("missing")
+		assert.EqualError(t, err, `unknown cluster "missing"`)
+	})
+}
+
+func TestNewClusterSetFromKubeconfig(t *testing.T) {
+	var authorization string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"kind":"Pod","apiVersion":"v1","metadata":{"name":"web","namespace":"audit"}}`))
+	}))
+	defer server.Close()
+
+	t.Run("builds one client per context", func(t *testing.T) {
+		loader := newMockLoader(testKubeconfig(server.URL))
+
+		set, err := NewClusterSetFromKubeconfig(loader, 4, WithUser("admin"))
+		require.NoError(t, err)
+		defer set.Close()
+
+		loader.AssertNumberOfCalls(t, "Load", 1)
+
+		assert.Equal(t, []string{"broken", "test"}, set.Clusters())
+
+		client, err := set.Client("test")
+		require.NoError(t, err)
+		assert.Equal(t, "audit", client.DefaultNamespace())
+
+		_, err = client.GetPodAPI().GetPodByName(context.Background(), "audit", "web")
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer admin-token", authorization)
+	})
+
+	t.Run("fails on invalid kubeconfig", func(t *testing.T) {
+		set, err := NewClusterSetFromKubeconfig(newMockLoader([]byte("invalid yaml")), 4)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to load kubeconfig")
+		assert.Nil(t, set)
+	})
+
+	t.Run("fails when loader fails", func(t *testing.T) {
+		loader := &mocksauth.MockK8sAuthLoader{}
+		loader.On("Load").Return(nil, errors.New("secret not found"))
+
+		set, err := NewClusterSetFromKubeconfig(loader, 4)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "secret not found")
+		assert.Nil(t, set)
+	})
+}
@@ -0,0 +1,104 @@
+package k8sclient
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kaudit/val"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+// configure records a change applied to the *rest.Config of the selected connection
+// before the clients are built, so it takes effect whichever connection option is used.
+func (k *K8sClient) configure(change func(cfg *rest.Config) error) {
+	k.configChanges = append(k.configChanges, change)
+}
+
+// applyConfig applies the recorded changes to cfg in the order the options were given.
+// It returns the first error of a change.
+func (k *K8sClient) applyConfig(cfg *rest.Config) error {
+	for _, change := range k.configChanges {
+		if err := change(cfg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WithQPS creates a K8sClientOption that sets the sustained number of queries per second
+// the client may send to the API server. client-go defaults to 5 QPS.
+//
+// The limit is ignored when a custom rate limiter is set with WithRateLimiter.
+func WithQPS(qps float32) K8sClientOption {
+	return func(k8sClient *K8sClient) error {
+		if err := val.ValidateWithTag(qps, "gt=0"); err != nil {
+			return fmt.Errorf("invalid qps: %w", err)
+		}
+
+		k8sClient.configure(func(cfg *rest.Config) error {
+			cfg.QPS = qps
+			return nil
+		})
+
+		return nil
+	}
+}
+
+// WithBurst creates a K8sClientOption that sets the number of queries the client may send
+// in a burst above its QPS. client-go defaults to a burst of 10.
+//
+// The limit is ignored when a custom rate limiter is set with WithRateLimiter.
+func WithBurst(burst int) K8sClientOption {
+	return func(k8sClient *K8sClient) error {
+		if err := val.ValidateWithTag(burst, "gt=0"); err != nil {
+			return fmt.Errorf("invalid burst: %w", err)
+		}
+
+		k8sClient.configure(func(cfg *rest.Config) error {
+			cfg.Burst = burst
+			return nil
+		})
+
+		return nil
+	}
+}
+
+// WithRateLimiter creates a K8sClientOption that throttles all requests of the client
+// through the given rate limiter instead of the QPS and burst settings.
+// Sharing one limiter between several clients caps their combined request rate.
+func WithRateLimiter(limiter flowcontrol.RateLimiter) K8sClientOption {
+	return func(k8sClient *K8sClient) error {
+		if limiter == nil {
+			return errors.New("invalid rate limiter: must not be nil")
+		}
+
+		k8sClient.configure(func(cfg *rest.Config) error {
+			cfg.RateLimiter = limiter
+			return nil
+		})
+
+		return nil
+	}
+}
+
+// WithRequestTimeout creates a K8sClientOption that limits the duration of every single
+// request to the API server, including each page of a paginated list.
+// Watch connections are cut after the timeout as well; watches resume automatically from
+// the last observed resourceVersion.
+func WithRequestTimeout(timeout time.Duration) K8sClientOption {
+	return func(k8sClient *K8sClient) error {
+		if err := val.ValidateWithTag(timeout, "gt=0"); err != nil {
+			return fmt.Errorf("invalid request timeout: %w", err)
+		}
+
+		k8sClient.configure(func(cfg *rest.Config) error {
+			cfg.Timeout = timeout
+			return nil
+		})
+
+		return nil
+	}
+}
@@ -0,0 +1,125 @@
+package k8sclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+// countingLimiter is a flowcontrol.RateLimiter that never blocks and counts waits.
+type countingLimiter struct {
+	waits atomic.Int32
+}
+
+var _ flowcontrol.RateLimiter = (*countingLimiter)(nil)
+
+func (c *countingLimiter) TryAccept() bool { return true }
+func (c *countingLimiter) Accept()         {}
+func (c *countingLimiter) Stop()           {}
+func (c *countingLimiter) QPS() float32    { return 1 }
+
+func (c *countingLimiter) Wait(context.Context) error {
+	c.waits.Add(1)
+	return nil
+}
+
+// withTestServer returns a connection option pointing the client at url.
+func withTestServer(url string) K8sClientOption {
+	return func(k *K8sClient) error {
+		return k.setConnector(func() (*connection, error) {
+			return &connection{config: &rest.Config{Host: url}}, nil
+		})
+	}
+}
+
+func TestConfigOptions(t *testing.T) {
+	limiter := &countingLimiter{}
+
+	tests := []struct {
+		name          string
+		options       []K8sClientOption
+		want          rest.Config
+		errorContains string
+	}{
+		{
+			name:    "QPS and burst",
+			options: []K8sClientOption{WithQPS(50), WithBurst(100)},
+			want:    rest.Config{QPS: 50, Burst: 100},
+		},
+		{
+			name:    "Rate limiter and timeout",
+			options: []K8sClientOption{WithRateLimiter(limiter), WithRequestTimeout(30 * time.Second)},
+			want:    rest.Config{RateLimiter: limiter, Timeout: 30 * time.Second},
+		},
+		{
+			name:          "Invalid QPS",
+			options:       []K8sClientOption{WithQPS(0)},
+			errorContains: "invalid qps",
+		},
+		{
+			name:          "Invalid burst",
+			options:       []K8sClientOption{WithBurst(-1)},
+			errorContains: "invalid burst",
+		},
+		{
+			name:          "Nil rate limiter",
+			options:       []K8sClientOption{WithRateLimiter(nil)},
+			errorContains: "invalid rate limiter",
+		},
+		{
+			name:          "Invalid timeout",
+			options:       []K8sClientOption{WithRequestTimeout(0)},
+			errorContains: "invalid request timeout",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &K8sClient{}
+			var err error
+			for _, option := range tt.options {
+				if err = option(client); err != nil {
+					break
+				}
+			}
+
+			if tt.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				return
+			}
+
+			require.NoError(t, err)
+			cfg := rest.Config{}
+			require.NoError(t, client.applyConfig(&cfg))
+			assert.Equal(t, tt.want, cfg)
+		})
+	}
+}
+
+func TestNewK8sClient_RateLimiter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"kind":"Pod","apiVersion":"v1","metadata":{"name":"web","namespace":"default"}}`))
+	}))
+	defer server.Close()
+
+	limiter := &countingLimiter{}
+
+	client, err := NewK8sClient(withTestServer(server.URL), WithRateLimiter(limiter))
+	require.NoError(t, err)
+	defer client.Close()
+
+	p, err := client.GetPodAPI().GetPodByName(context.Background(), "default", "web")
+	require.NoError(t, err)
+	assert.Equal(t, "web", p.Name)
+	assert.Equal(t, int32(1), limiter.waits.Load())
+}
@@ -0,0 +1,131 @@
+package k8sclient
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kaudit/val"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	api "github.com/kaudit/k8s_client"
+	"github.com/kaudit/k8s_client/internal/connection/kubeconfig"
+)
+
+// DefaultNamespace is the namespace used when neither the connection nor an option selects one.
+const DefaultNamespace = "default"
+
+// KubeContext describes a context of a kubeconfig together with the cluster and user it refers to.
+type KubeContext struct {
+	Name      string
+	Cluster   string
+	Server    string
+	User      string
+	Namespace string
+	Current   bool
+}
+
+// ListContexts returns the contexts of the kubeconfig provided by loader sorted by name.
+// It returns an error if loading or parsing the kubeconfig fails.
+func ListContexts(loader api.K8sAuthLoader) ([]KubeContext, error) {
+	cfg, err := kubeconfig.LoadConfig(loader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	contexts := make([]KubeContext, 0, len(cfg.Contexts))
+	for name, ctx := range cfg.Contexts {
+		kubeContext := KubeContext{
+			Name:      name,
+			Cluster:   ctx.Cluster,
+			User:      ctx.AuthInfo,
+			Namespace: ctx.Namespace,
+			Current:   name == cfg.CurrentContext,
+		}
+		if cluster, ok := cfg.Clusters[ctx.Cluster]; ok {
+			kubeContext.Server = cluster.Server
+		}
+
+		contexts = append(contexts, kubeContext)
+	}
+
+	sort.Slice(contexts, func(i, j int) bool {
+		return contexts[i].Name < contexts[j].Name
+	})
+
+	return contexts, nil
+}
+
+// WithContext creates a K8sClientOption that connects through the named kubeconfig context
+// instead of the current-context. It requires WithKubeConfigLoader.
+func WithContext(name string) K8sClientOption {
+	return func(k8sClient *K8sClient) error {
+		if err := val.ValidateWithTag(name, "required"); err != nil {
+			return fmt.Errorf("invalid context name: %w", err)
+		}
+
+		k8sClient.overrides.CurrentContext = name
+
+		return nil
+	}
+}
+
+// WithServer creates a K8sClientOption that overrides the API server URL of the connection,
+// for example to reach a cluster through a tunnel or load balancer.
+func WithServer(server string) K8sClientOption {
+	return func(k8sClient *K8sClient) error {
+		if err := val.ValidateWithTag(server, "required,url_prefix,url"); err != nil {
+			return fmt.Errorf("invalid server url: %w", err)
+		}
+
+		k8sClient.overrides.ClusterInfo.Server = server
+
+		return nil
+	}
+}
+
+// WithDefaultNamespace creates a K8sClientOption that overrides the default namespace of the
+// connection reported by K8sClient.DefaultNamespace.
+func WithDefaultNamespace(namespace string) K8sClientOption {
+	return func(k8sClient *K8sClient) error {
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			return fmt.Errorf("invalid default namespace %q: %w", namespace, errors.New(strings.Join(errs, "; ")))
+		}
+
+		k8sClient.overrides.Context.Namespace = namespace
+
+		return nil
+	}
+}
+
+// WithUser creates a K8sClientOption that authenticates as the named kubeconfig user instead
+// of the user of the selected context. It requires WithKubeConfigLoader.
+func WithUser(name string) K8sClientOption {
+	return func(k8sClient *K8sClient) error {
+		if err := val.ValidateWithTag(name, "required"); err != nil {
+			return fmt.Errorf("invalid user name: %w", err)
+		}
+
+		k8sClient.overrides.Context.AuthInfo = name
+
+		return nil
+	}
+}
+
+// DefaultNamespace returns the default namespace of the connection: the namespace set with
+// WithDefaultNamespace, otherwise the namespace of the selected kubeconfig context or, for
+// service account connections, the namespace of the pod, otherwise DefaultNamespace.
+func (k *K8sClient) DefaultNamespace() string {
+	return k.namespace
+}
+
+// resolveNamespace settles the default namespace after the connection was established.
+func (k *K8sClient) resolveNamespace() {
+	if k.overrides.Context.Namespace != "" {
+		k.namespace = k.overrides.Context.Namespace
+	}
+	if k.namespace == "" {
+		k.namespace = DefaultNamespace
+	}
+}
@@ -0,0 +1,172 @@
+package k8sclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mocksauth "github.com/kaudit/k8s_client/mocks/K8sAuthLoader"
+)
+
+// testKubeconfig returns a kubeconfig with a "broken" current-context and a "test" context
+// pointing at server, plus two users authenticating with different tokens. clientcmd only
+// attaches credentials to TLS connections, so server must use https.
+func testKubeconfig(server string) []byte {
+	return []byte(fmt.Sprintf(`
+apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: http://broken.invalid
+  name: broken
+- cluster:
+    server: %s
+    insecure-skip-tls-verify: true
+  name: test
+contexts:
+- context:
+    cluster: broken
+    user: auditor
+  name: broken
+- context:
+    cluster: test
+    namespace: audit
+    user: auditor
+  name: test
+current-context: broken
+users:
+- name: auditor
+  user:
+    token: auditor-token
+- name: admin
+  user:
+    token: admin-token
+`, server))
+}
+
+func newMockLoader(data []byte) *mocksauth.MockK8sAuthLoader {
+	loader := &mocksauth.MockK8sAuthLoader{}
+	loader.On("Load").Return(data, nil)
+
+	return loader
+}
+
+func TestListContexts(t *testing.T) {
+	t.Run("lists contexts sorted by name", func(t *testing.T) {
+		contexts, err := ListContexts(newMockLoader(testKubeconfig("https://test.example.com")))
+
+		require.NoError(t, err)
+		assert.Equal(t, []KubeContext{
+			{Name: "broken", Cluster: "broken", Server: "http://broken.invalid", User: "auditor", Current: true},
+			{Name: "test", Cluster: "test", Server: "https://test.example.com", User: "auditor", Namespace: "audit"},
+		}, contexts)
+	})
+
+	t.Run("fails on invalid kubeconfig", func(t *testing.T) {
+		contexts, err := ListContexts(newMockLoader([]byte("invalid yaml")))
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to load kubeconfig")
+		assert.Nil(t, contexts)
+	})
+}
+
+func TestNewK8sClient_KubeconfigOverrides(t *testing.T) {
+	var authorization string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"kind":"Pod","apiVersion":"v1","metadata":{"name":"web","namespace":"audit"}}`))
+	}))
+	defer server.Close()
+
+	loader := newMockLoader(testKubeconfig(server.URL))
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		options       []K8sClientOption
+		wantNamespace string
+		wantAuth      string
+	}{
+		{
+			name:          "Named context",
+			options:       []K8sClientOption{WithContext("test")},
+			wantNamespace: "audit",
+			wantAuth:      "Bearer auditor-token",
+		},
+		{
+			name: "Server, user and namespace overrides",
+			options: []K8sClientOption{
+				WithServer(server.URL), WithUser("admin"), WithDefaultNamespace("kube-system"), WithInsecureSkipVerify(),
+			},
+			wantNamespace: "kube-system",
+			wantAuth:      "Bearer admin-token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := append([]K8sClientOption{WithKubeConfigLoader(loader)}, tt.options...)
+
+			client, err := NewK8sClient(options...)
+			require.NoError(t, err)
+			defer client.Close()
+
+			assert.Equal(t, tt.wantNamespace, client.DefaultNamespace())
+			_, err = client.GetPodAPI().GetPodByName(ctx, client.DefaultNamespace(), "web")
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAuth, authorization)
+		})
+	}
+
+	t.Run("current context without namespace uses default", func(t *testing.T) {
+		client, err := NewK8sClient(WithKubeConfigLoader(loader))
+		require.NoError(t, err)
+
+		assert.Equal(t, DefaultNamespace, client.DefaultNamespace())
+	})
+
+	t.Run("unknown context", func(t *testing.T) {
+		client, err := NewK8sClient(WithKubeConfigLoader(loader), WithContext("missing"))
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to init k8s client")
+		assert.Nil(t, client)
+	})
+
+	t.Run("context selection with service account", func(t *testing.T) {
+		client, err := NewK8sClient(WithServiceAccount(), WithContext("test"))
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "require a kubeconfig connection")
+		assert.Nil(t, client)
+	})
+}
+
+func TestContextOptions_Invalid(t *testing.T) {
+	tests := []struct {
+		name          string
+		option        K8sClientOption
+		errorContains string
+	}{
+		{name: "Empty context", option: WithContext(""), errorContains: "invalid context name"},
+		{name: "Invalid server", option: WithServer("api.example.com"), errorContains: "invalid server url"},
+		{name: "Invalid namespace", option: WithDefaultNamespace("Not_A_Namespace"), errorContains: "invalid default namespace"},
+		{name: "Empty user", option: WithUser(""), errorContains: "invalid user name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.option(&K8sClient{})
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
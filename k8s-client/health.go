@@ -0,0 +1,85 @@
+package k8sclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+)
+
+// DefaultHealthTimeout bounds Ping and Health when ctx has no earlier deadline, so a dead
+// cluster is detected quickly instead of after the request timeout of the client.
+const DefaultHealthTimeout = 5 * time.Second
+
+// clusterIDNamespace is the namespace whose UID identifies a cluster. It exists in every
+// cluster and keeps its UID for the lifetime of the cluster.
+const clusterIDNamespace = "kube-system"
+
+// Health describes a reachable API server.
+type Health struct {
+	// Version is the version reported by the /version endpoint.
+	Version version.Info
+	// ClusterID is the UID of the kube-system namespace. It is the same for every kubeconfig,
+	// context or address reaching the same cluster.
+	ClusterID string
+	// Latency is the round trip time of the /readyz request.
+	Latency time.Duration
+}
+
+// Ping checks that the API server answers /readyz with success and returns the round trip time.
+// It returns an error if the server cannot be reached or is not ready within
+// DefaultHealthTimeout.
+func (k *K8sClient) Ping(ctx context.Context) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, DefaultHealthTimeout)
+	defer cancel()
+
+	return k.ping(ctx)
+}
+
+// Health checks that the API server is ready and returns its version and the identity of
+// the cluster, so callers can skip dead clusters and recognize the same cluster reached
+// through different kubeconfigs. All requests share DefaultHealthTimeout.
+//
+// Reading the cluster identity requires get permission on the kube-system namespace.
+// It returns an error if any of the requests fails.
+func (k *K8sClient) Health(ctx context.Context) (*Health, error) {
+	ctx, cancel := context.WithTimeout(ctx, DefaultHealthTimeout)
+	defer cancel()
+
+	latency, err := k.ping(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := k.clientset.Discovery().RESTClient().Get().AbsPath("/version").Do(ctx).Raw()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get server version: %w", err)
+	}
+
+	health := &Health{Latency: latency}
+	if err = json.Unmarshal(raw, &health.Version); err != nil {
+		return nil, fmt.Errorf("failed to decode server version: %w", err)
+	}
+
+	ns, err := k.clientset.CoreV1().Namespaces().Get(ctx, clusterIDNamespace, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster identity: %w", err)
+	}
+	health.ClusterID = string(ns.UID)
+
+	return health, nil
+}
+
+// ping requests /readyz and returns its round trip time.
+func (k *K8sClient) ping(ctx context.Context) (time.Duration, error) {
+	start := time.Now()
+
+	if err := k.clientset.Discovery().RESTClient().Get().AbsPath("/readyz").Do(ctx).Error(); err != nil {
+		return 0, fmt.Errorf("API server not ready: %w", err)
+	}
+
+	return time.Since(start), nil
+}
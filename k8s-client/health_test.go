@@ -0,0 +1,141 @@
+package k8sclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// healthServer returns a server answering /readyz with readyz, /version and the kube-system
+// namespace with namespaceStatus.
+func healthServer(t *testing.T, readyz, namespaceStatus int) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/readyz":
+			if readyz == 0 {
+				<-r.Context().Done()
+				return
+			}
+			w.WriteHeader(readyz)
+			_, _ = w.Write([]byte("ok"))
+		case "/version":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"major":"1","minor":"32","gitVersion":"v1.32.4","platform":"linux/amd64"}`))
+		case "/api/v1/namespaces/kube-system":
+			w.Header().Set("Content-Type", "application/json")
+			if namespaceStatus != http.StatusOK {
+				w.WriteHeader(namespaceStatus)
+				_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Forbidden","code":403}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"kind":"Namespace","apiVersion":"v1","metadata":{"name":"kube-system","uid":"4f1c2a9e-0d3b-4c55-9a7e-2b6f8d1e3c70"}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestK8sClient_Health(t *testing.T) {
+	tests := []struct {
+		name            string
+		readyz          int
+		namespaceStatus int
+		timeout         time.Duration
+		errorContains   string
+	}{
+		{
+			name:            "Healthy cluster",
+			readyz:          http.StatusOK,
+			namespaceStatus: http.StatusOK,
+		},
+		{
+			name:            "Not ready",
+			readyz:          http.StatusInternalServerError,
+			namespaceStatus: http.StatusOK,
+			errorContains:   "API server not ready",
+		},
+		{
+			name:            "Unresponsive",
+			namespaceStatus: http.StatusOK,
+			timeout:         50 * time.Millisecond,
+			errorContains:   "API server not ready",
+		},
+		{
+			name:            "Cluster identity forbidden",
+			readyz:          http.StatusOK,
+			namespaceStatus: http.StatusForbidden,
+			errorContains:   "failed to get cluster identity",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := healthServer(t, tt.readyz, tt.namespaceStatus)
+
+			client, err := NewK8sClient(withTestServer(server.URL))
+			require.NoError(t, err)
+			defer client.Close()
+
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			health, err := client.Health(ctx)
+			if tt.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, health)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, "v1.32.4", health.Version.GitVersion)
+			assert.Equal(t, "32", health.Version.Minor)
+			assert.Equal(t, "4f1c2a9e-0d3b-4c55-9a7e-2b6f8d1e3c70", health.ClusterID)
+			assert.Positive(t, health.Latency)
+		})
+	}
+}
+
+func TestK8sClient_Ping(t *testing.T) {
+	t.Run("returns latency of ready server", func(t *testing.T) {
+		server := healthServer(t, http.StatusOK, http.StatusForbidden)
+
+		client, err := NewK8sClient(withTestServer(server.URL))
+		require.NoError(t, err)
+		defer client.Close()
+
+		latency, err := client.Ping(context.Background())
+
+		require.NoError(t, err)
+		assert.Positive(t, latency)
+	})
+
+	t.Run("fails when server is gone", func(t *testing.T) {
+		server := healthServer(t, http.StatusOK, http.StatusOK)
+
+		client, err := NewK8sClient(withTestServer(server.URL))
+		require.NoError(t, err)
+		defer client.Close()
+		server.Close()
+
+		latency, err := client.Ping(context.Background())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "API server not ready")
+		assert.Zero(t, latency)
+	})
+}
@@ -0,0 +1,108 @@
+package k8sclient
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kaudit/val"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/rest"
+)
+
+// Impersonation describes the identity requests are made as instead of the authenticated user.
+// The authenticated user needs the impersonate permission for the user, groups, UID and extra
+// fields given.
+type Impersonation struct {
+	UserName string `validate:"required"`
+	UID      string
+	Groups   []string            `validate:"dive,required"`
+	Extra    map[string][]string `validate:"dive,keys,required,endkeys"`
+}
+
+// ImpersonateServiceAccount returns the Impersonation of the ServiceAccount name in namespace,
+// with the groups the API server assigns to service account tokens.
+func ImpersonateServiceAccount(namespace, name string) Impersonation {
+	return Impersonation{
+		UserName: fmt.Sprintf("system:serviceaccount:%s:%s", namespace, name),
+		Groups: []string{
+			"system:serviceaccounts",
+			"system:serviceaccounts:" + namespace,
+			"system:authenticated",
+		},
+	}
+}
+
+// config returns the client-go form of the impersonation.
+func (i Impersonation) config() rest.ImpersonationConfig {
+	return rest.ImpersonationConfig{
+		UserName: i.UserName,
+		UID:      i.UID,
+		Groups:   i.Groups,
+		Extra:    i.Extra,
+	}
+}
+
+// WithImpersonation creates a K8sClientOption that makes every request of the client as
+// the given identity, e.g. ImpersonateServiceAccount("audit", "reader").
+//
+// Use Impersonate instead to compare the view of another identity with the client's own.
+func WithImpersonation(impersonation Impersonation) K8sClientOption {
+	return func(k8sClient *K8sClient) error {
+		if err := val.ValidateStruct(impersonation); err != nil {
+			return fmt.Errorf("invalid impersonation: %w", err)
+		}
+
+		k8sClient.configure(func(cfg *rest.Config) error {
+			cfg.Impersonate = impersonation.config()
+			return nil
+		})
+
+		return nil
+	}
+}
+
+// Impersonate returns a client that makes the requests of all four APIs as the given identity,
+// sharing the connection, default namespace, retry policy and logger of k. The impersonation replaces
+// one set with WithImpersonation.
+//
+// The returned client always reads from the API server, even when k uses the informer cache,
+// so its results reflect what the impersonated identity is allowed to see. Closing it does not
+// affect k.
+//
+// It returns an error when k reloads its credentials with WithCredentialReload and already
+// impersonates, through WithImpersonation or the kubeconfig, as that impersonation cannot be
+// replaced then.
+func (k *K8sClient) Impersonate(impersonation Impersonation) (*K8sClient, error) {
+	if err := val.ValidateStruct(impersonation); err != nil {
+		return nil, fmt.Errorf("invalid impersonation: %w", err)
+	}
+
+	if k.reloader != nil && k.reloader.Impersonates() {
+		return nil, errors.New("cannot replace the impersonation of a client with credential reloading")
+	}
+
+	cfg := rest.CopyConfig(k.config)
+	cfg.Impersonate = impersonation.config()
+
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes.NewForConfig failed: %w", err)
+	}
+
+	metadataClient, err := metadata.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("metadata.NewForConfig failed: %w", err)
+	}
+
+	client := &K8sClient{
+		retry:     k.retry,
+		namespace: k.namespace,
+		source:    k.source,
+		logger:    k.logger,
+		config:    cfg,
+	}
+	client.setup(clientset, metadataClient)
+
+	return client, nil
+}
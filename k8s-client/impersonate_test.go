@@ -0,0 +1,209 @@
+package k8sclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+)
+
+// impersonationServer returns a server answering pod reads and the impersonation headers of
+// the last request.
+func impersonationServer(t *testing.T) (*httptest.Server, func() http.Header) {
+	t.Helper()
+
+	var (
+		mu     sync.Mutex
+		header http.Header
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		header = http.Header{}
+		for key, values := range r.Header {
+			if strings.HasPrefix(key, "Impersonate-") {
+				header[key] = values
+			}
+		}
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"kind":"Pod","apiVersion":"v1","metadata":{"name":"web","namespace":"audit"}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() http.Header {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return header
+	}
+}
+
+func TestImpersonateServiceAccount(t *testing.T) {
+	assert.Equal(t, Impersonation{
+		UserName: "system:serviceaccount:audit:reader",
+		Groups:   []string{"system:serviceaccounts", "system:serviceaccounts:audit", "system:authenticated"},
+	}, ImpersonateServiceAccount("audit", "reader"))
+}
+
+func TestWithImpersonation(t *testing.T) {
+	server, headers := impersonationServer(t)
+
+	tests := []struct {
+		name          string
+		impersonation Impersonation
+		want          http.Header
+		errorContains string
+	}{
+		{
+			name:          "Service account",
+			impersonation: ImpersonateServiceAccount("audit", "reader"),
+			want: http.Header{
+				"Impersonate-User": {"system:serviceaccount:audit:reader"},
+				"Impersonate-Group": {
+					"system:serviceaccounts", "system:serviceaccounts:audit", "system:authenticated",
+				},
+			},
+		},
+		{
+			name: "User with UID and extra",
+			impersonation: Impersonation{
+				UserName: "jane",
+				UID:      "1234",
+				Extra:    map[string][]string{"scopes": {"view"}},
+			},
+			want: http.Header{
+				"Impersonate-User":         {"jane"},
+				"Impersonate-Uid":          {"1234"},
+				"Impersonate-Extra-Scopes": {"view"},
+			},
+		},
+		{
+			name:          "Missing user name",
+			impersonation: Impersonation{Groups: []string{"system:masters"}},
+			errorContains: "invalid impersonation",
+		},
+		{
+			name:          "Empty group",
+			impersonation: Impersonation{UserName: "jane", Groups: []string{""}},
+			errorContains: "invalid impersonation",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewK8sClient(withTestServer(server.URL), WithImpersonation(tt.impersonation))
+			if tt.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, client)
+				return
+			}
+			require.NoError(t, err)
+			defer client.Close()
+
+			_, err = client.GetPodAPI().GetPodByName(context.Background(), "audit", "web")
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, headers())
+		})
+	}
+}
+
+func TestK8sClient_Impersonate(t *testing.T) {
+	server, headers := impersonationServer(t)
+
+	t.Run("derived client impersonates and parent does not", func(t *testing.T) {
+		client, err := NewK8sClient(withTestServer(server.URL), WithDefaultNamespace("audit"))
+		require.NoError(t, err)
+		defer client.Close()
+
+		reader, err := client.Impersonate(ImpersonateServiceAccount("audit", "reader"))
+		require.NoError(t, err)
+		defer reader.Close()
+
+		assert.Equal(t, "audit", reader.DefaultNamespace())
+
+		_, err = reader.GetPodAPI().GetPodByName(context.Background(), "audit", "web")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"system:serviceaccount:audit:reader"}, headers()["Impersonate-User"])
+
+		_, err = client.GetPodAPI().GetPodByName(context.Background(), "audit", "web")
+		require.NoError(t, err)
+		assert.Empty(t, headers())
+	})
+
+	t.Run("replaces client impersonation", func(t *testing.T) {
+		client, err := NewK8sClient(withTestServer(server.URL), WithImpersonation(Impersonation{UserName: "jane"}))
+		require.NoError(t, err)
+		defer client.Close()
+
+		other, err := client.Impersonate(Impersonation{UserName: "joe"})
+		require.NoError(t, err)
+		defer other.Close()
+
+		_, err = other.GetPodAPI().GetPodByName(context.Background(), "audit", "web")
+		require.NoError(t, err)
+		assert.Equal(t, http.Header{"Impersonate-User": {"joe"}}, headers())
+	})
+
+	t.Run("reads from API server with informer cache", func(t *testing.T) {
+		client, err := NewK8sClient(withTestServer(server.URL), WithInformerCache(0))
+		require.NoError(t, err)
+		defer client.Close()
+
+		other, err := client.Impersonate(Impersonation{UserName: "joe"})
+		require.NoError(t, err)
+		defer other.Close()
+
+		assert.Nil(t, other.factory)
+	})
+
+	t.Run("fails on invalid impersonation", func(t *testing.T) {
+		client, err := NewK8sClient(withTestServer(server.URL))
+		require.NoError(t, err)
+		defer client.Close()
+
+		other, err := client.Impersonate(Impersonation{})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid impersonation")
+		assert.Nil(t, other)
+	})
+
+	t.Run("fails when reloaded credentials impersonate", func(t *testing.T) {
+		client, err := NewK8sClient(withTestServer(server.URL),
+			WithImpersonation(Impersonation{UserName: "jane"}), WithCredentialReload(0))
+		require.NoError(t, err)
+		defer client.Close()
+
+		other, err := client.Impersonate(Impersonation{UserName: "joe"})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "credential reloading")
+		assert.Nil(t, other)
+	})
+
+	t.Run("keeps reloaded credentials", func(t *testing.T) {
+		client, err := NewK8sClient(withTestServer(server.URL), WithCredentialReload(0))
+		require.NoError(t, err)
+		defer client.Close()
+
+		other, err := client.Impersonate(Impersonation{UserName: "joe"})
+		require.NoError(t, err)
+		defer other.Close()
+
+		assert.Equal(t, rest.ImpersonationConfig{UserName: "joe"}, other.config.Impersonate)
+
+		_, err = other.GetPodAPI().GetPodByName(context.Background(), "audit", "web")
+		require.NoError(t, err)
+		assert.Equal(t, http.Header{"Impersonate-User": {"joe"}}, headers())
+	})
+}
@@ -1,21 +1,39 @@
 package k8sclient
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io/fs"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/kaudit/val"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
 
 	api "github.com/kaudit/k8s_client"
 	"github.com/kaudit/k8s_client/internal/api/deployment"
 	"github.com/kaudit/k8s_client/internal/api/namespace"
+	"github.com/kaudit/k8s_client/internal/api/options"
 	"github.com/kaudit/k8s_client/internal/api/pod"
 	"github.com/kaudit/k8s_client/internal/api/service"
 	"github.com/kaudit/k8s_client/internal/connection/kubeconfig"
+	"github.com/kaudit/k8s_client/internal/connection/reloading"
 	"github.com/kaudit/k8s_client/internal/connection/serviceaccount"
 )
 
-var ErrAlreadyConfigured = errors.New("k8s client already configured")
+var (
+	ErrAlreadyConfigured = errors.New("k8s client already configured")
+	ErrNotConfigured     = errors.New("k8s client connection not configured")
+)
 
 // K8sClient provides a centralized access point to high-level Kubernetes API abstractions.
 //
@@ -23,78 +41,215 @@ var ErrAlreadyConfigured = errors.New("k8s client already configured")
 // and Namespaces — each exposed through domain-specific interface contracts.
 //
 // All API implementations are stateless, thread-safe, and validated via typed input contracts.
+// When the informer cache is enabled, reads are served from shared informers and the client
+// must be closed to stop them.
 type K8sClient struct {
 	pods        api.PodAPI        `validator:"required"`
 	services    api.ServiceAPI    `validator:"required"`
 	deployments api.DeploymentAPI `validator:"required"`
 	namespaces  api.NamespaceAPI  `validator:"required"`
+	clientset   kubernetes.Interface
+	config      *rest.Config
+
+	connect   connector
+	cache     *cacheConfig
+	retry     api.RetryPolicy
+	overrides clientcmd.ConfigOverrides
+	namespace string
+	source    ConnectionSource
+	reload    *reloadConfig
+	reloader  *reloading.Reloader
+	logger    *slog.Logger
+
+	configChanges []func(cfg *rest.Config) error
+
+	factory   informers.SharedInformerFactory
+	stopCh    chan struct{}
+	closeOnce sync.Once
+	insecure  bool
 }
 
 type K8sClientOption func(*K8sClient) error
 
-func validateNilK8sClient(k8sClient *K8sClient) bool {
-	if k8sClient == nil {
-		return true
-	}
+// connector establishes the connection selected by a connection option.
+// It may be called again to reload the credentials, so it must not modify the client.
+type connector func() (*connection, error)
+
+// connection is the result of a connector.
+type connection struct {
+	config    *rest.Config
+	namespace string
+	source    ConnectionSource
+}
 
-	if k8sClient.pods == nil || k8sClient.services == nil ||
-		k8sClient.deployments == nil || k8sClient.namespaces == nil {
+// cacheConfig holds the settings of the informer-backed cache mode.
+// An empty namespace caches all namespaces.
+type cacheConfig struct {
+	namespace string
+	resync    time.Duration
+}
 
-		return true
+// setConnector records the connection used to build the client.
+// Only one connection option may be applied to a client.
+func (k *K8sClient) setConnector(c connector) error {
+	if k.connect != nil {
+		return ErrAlreadyConfigured
 	}
 
-	return false
+	k.connect = c
+
+	return nil
 }
 
 // WithKubeConfigLoader creates a K8sClientOption that configures the client to use
 // authentication via a kubeconfig file. This option requires a K8sAuthLoader implementation
 // that can load the kubeconfig data.
 //
-// This option is mutually exclusive with WithServiceAccount. Using both options in the same
-// client initialization will result in the last applied option overriding previous authentication
-// configuration.
+// This option is mutually exclusive with WithServiceAccount, WithTokenAuth and WithAutoDetect.
+// Applying more than one connection option to the same client results in ErrAlreadyConfigured.
 func WithKubeConfigLoader(loader api.K8sAuthLoader) K8sClientOption {
 	return func(k8sClient *K8sClient) error {
-		if !validateNilK8sClient(k8sClient) {
-			return ErrAlreadyConfigured
+		return k8sClient.setConnector(func() (*connection, error) {
+			return k8sClient.connectKubeConfig(loader)
+		})
+	}
+}
+
+// WithServiceAccount creates a K8sClientOption that configures the client to use
+// in-cluster authentication via the service account token mounted in the pod.
+// This option should be used when the application is running inside a Kubernetes cluster.
+// The namespace of the pod becomes the default namespace of the client.
+//
+// By default the service account is read from /var/run/secrets/kubernetes.io/serviceaccount
+// and the API server address from the KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT
+// environment variables; options change either.
+//
+// This option is mutually exclusive with WithKubeConfigLoader, WithTokenAuth and WithAutoDetect.
+// Applying more than one connection option to the same client results in ErrAlreadyConfigured.
+func WithServiceAccount(options ...ServiceAccountOption) K8sClientOption {
+	return func(k8sClient *K8sClient) error {
+		var opts []serviceaccount.Option
+		for _, option := range options {
+			opt, err := option()
+			if err != nil {
+				return err
+			}
+
+			opts = append(opts, opt)
 		}
 
-		n, err := kubeconfig.NewKubeConfigConnection(loader).NativeAPI()
-		if err != nil {
-			return fmt.Errorf("failed to init k8s client: %w", err)
+		return k8sClient.setConnector(func() (*connection, error) {
+			return k8sClient.connectServiceAccount(opts...)
+		})
+	}
+}
+
+// connectKubeConfig connects with the kubeconfig provided by loader,
+// applying the context and server overrides of the client.
+func (k *K8sClient) connectKubeConfig(loader api.K8sAuthLoader) (*connection, error) {
+	conn := kubeconfig.NewKubeConfigConnection(loader, kubeconfig.WithOverrides(k.overrides))
+
+	cfg, namespace, err := conn.Config()
+	if err != nil {
+		return nil, fmt.Errorf("failed to init k8s client: %w", err)
+	}
+
+	return &connection{config: cfg, namespace: namespace, source: ConnectionSourceKubeconfig}, nil
+}
+
+// connectServiceAccount connects with the in-cluster service account of the pod.
+// A missing namespace file leaves the default namespace to resolveNamespace.
+func (k *K8sClient) connectServiceAccount(opts ...serviceaccount.Option) (*connection, error) {
+	if err := k.requireNoKubeconfigSelection(); err != nil {
+		return nil, err
+	}
+
+	conn := serviceaccount.NewServiceAccountConnection(opts...)
+
+	cfg, err := conn.RestConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to init k8s client with service account: %w", err)
+	}
+
+	namespace, err := conn.Namespace()
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read pod namespace: %w", err)
+	}
+
+	if server := k.overrides.ClusterInfo.Server; server != "" {
+		cfg.Host = server
+	}
+
+	return &connection{config: cfg, namespace: namespace, source: ConnectionSourceServiceAccount}, nil
+}
+
+// requireNoKubeconfigSelection fails if a kubeconfig context or user was selected for
+// a connection that is not based on a kubeconfig.
+func (k *K8sClient) requireNoKubeconfigSelection() error {
+	if k.overrides.CurrentContext != "" || k.overrides.Context.AuthInfo != "" {
+		return errors.New("context and user selection require a kubeconfig connection")
+	}
+
+	return nil
+}
+
+// WithInformerCache creates a K8sClientOption that backs the Get* and List* methods of all
+// four APIs with shared informers. After the initial sync, reads are served from an in-memory
+// cache instead of the API server; snapshots and watches still go to the API server.
+//
+// A resync of zero disables periodic resyncs. Call WaitForCacheSync before the first read
+// and Close to stop the informers.
+//
+// The informers list and watch pods, services, deployments and namespaces across the whole
+// cluster and hold all of them in memory. Credentials restricted to some namespaces never
+// complete the sync; use WithNamespacedInformerCache for them.
+func WithInformerCache(resync time.Duration) K8sClientOption {
+	return func(k8sClient *K8sClient) error {
+		if err := val.ValidateWithTag(resync, "gte=0"); err != nil {
+			return fmt.Errorf("invalid resync period: %w", err)
 		}
 
-		k8sClient.pods = pod.NewPodAPI(n)
-		k8sClient.services = service.NewServiceAPI(n)
-		k8sClient.deployments = deployment.NewDeploymentAPI(n)
-		k8sClient.namespaces = namespace.NewNamespaceAPI(n)
+		k8sClient.cache = &cacheConfig{resync: resync}
 
 		return nil
 	}
 }
 
-// WithServiceAccount creates a K8sClientOption that configures the client to use
-// in-cluster authentication via the service account token mounted in the pod.
-// This option should be used when the application is running inside a Kubernetes cluster.
+// WithNamespacedInformerCache creates a K8sClientOption like WithInformerCache whose informers
+// only list and watch pods, services and deployments of namespace, so list and watch access
+// to that namespace is all it needs. Reads of other namespaces and all namespace reads go to
+// the API server.
 //
-// This option is mutually exclusive with WithKubeConfigLoader. Using both options in the same
-// client initialization will result in the last applied option overriding previous authentication
-// configuration.
-func WithServiceAccount() K8sClientOption {
+// It replaces an informer cache set by an earlier option.
+func WithNamespacedInformerCache(namespace string, resync time.Duration) K8sClientOption {
 	return func(k8sClient *K8sClient) error {
-		if !validateNilK8sClient(k8sClient) {
-			return ErrAlreadyConfigured
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			return fmt.Errorf("invalid cache namespace %q: %w", namespace, errors.New(strings.Join(errs, "; ")))
+		}
+		if err := val.ValidateWithTag(resync, "gte=0"); err != nil {
+			return fmt.Errorf("invalid resync period: %w", err)
 		}
 
-		n, err := serviceaccount.ServiceAccountConnectionNativeAPI()
-		if err != nil {
-			return fmt.Errorf("failed to init k8s client with service account: %w", err)
+		k8sClient.cache = &cacheConfig{namespace: namespace, resync: resync}
+
+		return nil
+	}
+}
+
+// WithRetryPolicy creates a K8sClientOption that retries transient API failures of Get calls
+// and of every page of List calls under the given policy. Throttling (429), unavailable (503),
+// timeouts and dropped connections are retried with exponential backoff and jitter, honoring
+// Retry-After; validation failures and other 4xx responses are returned immediately.
+//
+// Without this option every call is attempted once. api.DefaultRetryPolicy provides a
+// reasonable starting point.
+func WithRetryPolicy(policy api.RetryPolicy) K8sClientOption {
+	return func(k8sClient *K8sClient) error {
+		if err := val.ValidateStruct(policy); err != nil {
+			return fmt.Errorf("invalid retry policy: %w", err)
 		}
 
-		k8sClient.pods = pod.NewPodAPI(n)
-		k8sClient.services = service.NewServiceAPI(n)
-		k8sClient.deployments = deployment.NewDeploymentAPI(n)
-		k8sClient.namespaces = namespace.NewNamespaceAPI(n)
+		k8sClient.retry = policy
 
 		return nil
 	}
@@ -109,14 +264,142 @@ func NewK8sClient(options ...K8sClientOption) (*K8sClient, error) {
 		}
 	}
 
-	err := val.ValidateStruct(client)
+	if client.connect == nil {
+		return nil, ErrNotConfigured
+	}
+
+	cfg, err := client.restConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure k8s client: %w", err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("kubernetes.NewForConfig failed: %w", err)
+	}
+
+	metadataClient, err := metadata.NewForConfig(cfg)
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("metadata.NewForConfig failed: %w", err)
+	}
+
+	client.config = cfg
+	client.setup(clientset, metadataClient)
+
+	err = val.ValidateStruct(client)
 	if err != nil {
+		client.Close()
 		return nil, fmt.Errorf("failed to validate k8s client: %w", err)
 	}
 
 	return client, nil
 }
 
+// restConfig establishes the connection and returns the *rest.Config all clients are built
+// from, with the changes of the options applied and, if enabled, credential reloading.
+func (k *K8sClient) restConfig() (*rest.Config, error) {
+	conn, err := k.connect()
+	if err != nil {
+		return nil, err
+	}
+
+	k.namespace = conn.namespace
+	k.source = conn.source
+	k.resolveNamespace()
+
+	if err = k.applyConfig(conn.config); err != nil {
+		return nil, err
+	}
+
+	if k.insecure {
+		k.warnInsecure(conn.config.Host)
+	}
+
+	if k.reload == nil {
+		return conn.config, nil
+	}
+
+	return k.startReload(conn.config)
+}
+
+// setup wires the resource APIs to the given clients, starting the shared informers
+// when the cache mode is enabled.
+func (k *K8sClient) setup(clientset kubernetes.Interface, metadataClient metadata.Interface) {
+	k.clientset = clientset
+
+	opts := []options.Option{
+		options.WithMetadataClient(metadataClient),
+		options.WithRetryPolicy(k.retry),
+		options.WithLogger(k.logger),
+	}
+
+	if k.cache == nil {
+		k.pods = pod.NewPodAPI(clientset, opts...)
+		k.services = service.NewServiceAPI(clientset, opts...)
+		k.deployments = deployment.NewDeploymentAPI(clientset, opts...)
+		k.namespaces = namespace.NewNamespaceAPI(clientset, opts...)
+
+		return
+	}
+
+	k.factory = informers.NewSharedInformerFactoryWithOptions(clientset, k.cache.resync,
+		informers.WithNamespace(k.cache.namespace))
+	k.stopCh = make(chan struct{})
+
+	if k.cache.namespace == "" {
+		k.namespaces = namespace.NewCachedNamespaceAPI(clientset, k.factory, opts...)
+	} else {
+		k.namespaces = namespace.NewNamespaceAPI(clientset, opts...)
+		opts = append(opts, options.WithCacheNamespace(k.cache.namespace))
+	}
+
+	k.pods = pod.NewCachedPodAPI(clientset, k.factory, opts...)
+	k.services = service.NewCachedServiceAPI(clientset, k.factory, opts...)
+	k.deployments = deployment.NewCachedDeploymentAPI(clientset, k.factory, opts...)
+
+	k.factory.Start(k.stopCh)
+}
+
+// WaitForCacheSync blocks until the informer caches have completed their initial sync or
+// ctx is done. It returns an error naming the informers that did not sync in time.
+// Without the informer cache it returns immediately.
+func (k *K8sClient) WaitForCacheSync(ctx context.Context) error {
+	if k.factory == nil {
+		return nil
+	}
+
+	var unsynced []string
+	for informerType, synced := range k.factory.WaitForCacheSync(ctx.Done()) {
+		if !synced {
+			unsynced = append(unsynced, informerType.String())
+		}
+	}
+
+	if len(unsynced) > 0 {
+		sort.Strings(unsynced)
+		return fmt.Errorf("informer caches not synced: %s", strings.Join(unsynced, ", "))
+	}
+
+	return nil
+}
+
+// Close stops the shared informers and credential reloading, and waits for them to exit.
+// It is safe to call Close multiple times and on clients without the informer cache.
+func (k *K8sClient) Close() {
+	k.closeOnce.Do(func() {
+		if k.factory != nil {
+			close(k.stopCh)
+			k.factory.Shutdown()
+		}
+
+		if k.reloader != nil {
+			k.reloader.Stop()
+		}
+	})
+}
+
 // GetPodAPI exposes the PodAPI interface, allowing access to pod-specific operations.
 func (k *K8sClient) GetPodAPI() api.PodAPI { return k.pods }
 
@@ -130,6 +413,13 @@ func (k *K8sClient) GetDeploymentAPI() api.DeploymentAPI {
 	return k.deployments
 }
 
+// GetClientset exposes the underlying client-go clientset for resources the typed APIs do not
+// cover, such as reading the Secrets a secret.SecretLoader bootstraps other clients from.
+// Requests made through it bypass the informer cache and the retry policy.
+func (k *K8sClient) GetClientset() kubernetes.Interface {
+	return k.clientset
+}
+
 // GetNamespaceAPI exposes the NamespaceAPI interface for managing namespaces.
 func (k *K8sClient) GetNamespaceAPI() api.NamespaceAPI {
 	return k.namespaces
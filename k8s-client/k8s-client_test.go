@@ -1 +1,144 @@
 package k8sclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	api "github.com/kaudit/k8s_client"
+	"github.com/kaudit/k8s_client/internal/api/namespace"
+	"github.com/kaudit/k8s_client/internal/api/pod"
+)
+
+// newTestClient applies options to a client and wires it to the given fake clientset,
+// bypassing the connection step of NewK8sClient.
+func newTestClient(t *testing.T, clientset *fake.Clientset, options ...K8sClientOption) *K8sClient {
+	t.Helper()
+
+	client := &K8sClient{}
+	for _, option := range options {
+		require.NoError(t, option(client))
+	}
+
+	client.setup(clientset, nil)
+	t.Cleanup(client.Close)
+
+	return client
+}
+
+func TestNewK8sClient(t *testing.T) {
+	t.Run("fails without connection option", func(t *testing.T) {
+		client, err := NewK8sClient()
+
+		require.ErrorIs(t, err, ErrNotConfigured)
+		assert.Nil(t, client)
+	})
+
+	t.Run("fails with multiple connection options", func(t *testing.T) {
+		client, err := NewK8sClient(WithServiceAccount(), WithServiceAccount())
+
+		require.ErrorIs(t, err, ErrAlreadyConfigured)
+		assert.Nil(t, client)
+	})
+
+	t.Run("wraps connection errors", func(t *testing.T) {
+		failing := func(k *K8sClient) error {
+			return k.setConnector(func() (*connection, error) {
+				return nil, assert.AnError
+			})
+		}
+
+		client, err := NewK8sClient(failing)
+
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, client)
+	})
+
+	t.Run("rejects negative resync period", func(t *testing.T) {
+		client, err := NewK8sClient(WithInformerCache(-time.Second))
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid resync period")
+		assert.Nil(t, client)
+	})
+
+	t.Run("rejects invalid cache namespace", func(t *testing.T) {
+		client, err := NewK8sClient(WithNamespacedInformerCache("Team_A", 0))
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid cache namespace")
+		assert.Nil(t, client)
+	})
+
+	t.Run("rejects invalid retry policy", func(t *testing.T) {
+		policy := api.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Second, MaxBackoff: time.Millisecond}
+
+		client, err := NewK8sClient(WithRetryPolicy(policy))
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid retry policy")
+		assert.Nil(t, client)
+	})
+}
+
+func TestK8sClient_InformerCache(t *testing.T) {
+	clientset := fake.NewClientset(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "test-namespace"},
+	})
+
+	t.Run("serves reads from synced cache", func(t *testing.T) {
+		client := newTestClient(t, clientset, WithInformerCache(0))
+
+		_, ok := client.GetPodAPI().(*pod.CachedPodAPI)
+		require.True(t, ok)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		require.NoError(t, client.WaitForCacheSync(ctx))
+
+		p, err := client.GetPodAPI().GetPodByName(ctx, "test-namespace", "web-1")
+		require.NoError(t, err)
+		assert.Equal(t, "web-1", p.Name)
+
+		client.Close()
+		client.Close()
+	})
+
+	t.Run("restricts cache to namespace", func(t *testing.T) {
+		client := newTestClient(t, clientset, WithNamespacedInformerCache("other-namespace", 0))
+
+		_, ok := client.GetPodAPI().(*pod.CachedPodAPI)
+		require.True(t, ok)
+		_, ok = client.GetNamespaceAPI().(*namespace.NamespaceAPI)
+		require.True(t, ok)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		require.NoError(t, client.WaitForCacheSync(ctx))
+
+		p, err := client.GetPodAPI().GetPodByName(ctx, "test-namespace", "web-1")
+		require.NoError(t, err)
+		assert.Equal(t, "web-1", p.Name)
+	})
+
+	t.Run("uses live API without cache", func(t *testing.T) {
+		client := newTestClient(t, clientset)
+
+		_, ok := client.GetPodAPI().(*pod.PodAPI)
+		require.True(t, ok)
+		require.NoError(t, client.WaitForCacheSync(context.Background()))
+	})
+}
+
+func TestK8sClient_GetClientset(t *testing.T) {
+	clientset := fake.NewClientset()
+	client := newTestClient(t, clientset)
+
+	assert.Same(t, clientset, client.GetClientset())
+}
@@ -0,0 +1,28 @@
+package k8sclient
+
+import (
+	"errors"
+	"log/slog"
+)
+
+// WithLogger creates a K8sClientOption that emits structured debug logs to logger for every
+// request of the four APIs: the operation, resource kind, namespace, name or selectors, each
+// list page, the number of pages and items, the duration and, for failures, the error class
+// such as "forbidden" or "timeout". Failed credential reloads are logged as warnings with
+// a reason such as "load_failed", and connections without certificate verification as a
+// warning naming the server.
+//
+// Only request metadata is logged; credentials, continue tokens, error messages and object
+// contents never are. Reads served from the informer cache are not logged.
+// Without this option the APIs log nothing.
+func WithLogger(logger *slog.Logger) K8sClientOption {
+	return func(k8sClient *K8sClient) error {
+		if logger == nil {
+			return errors.New("invalid logger: must not be nil")
+		}
+
+		k8sClient.logger = logger
+
+		return nil
+	}
+}
@@ -0,0 +1,80 @@
+package k8sclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/pem"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/kaudit/k8s_client"
+)
+
+func TestWithLogger(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/v1/namespaces/audit/pods/gone" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"kind":"Pod","apiVersion":"v1","metadata":{"name":"web","namespace":"audit"}}`))
+	}))
+	defer server.Close()
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	t.Run("logs requests without credentials", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+		client, err := NewK8sClient(
+			WithTokenAuth(TokenAuth{Server: server.URL, CAData: caPEM, Token: "super-secret-token"}),
+			WithLogger(logger))
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.GetPodAPI().GetPodByName(context.Background(), "audit", "web")
+		require.NoError(t, err)
+		_, err = client.GetPodAPI().GetPodByName(context.Background(), "audit", "gone")
+		require.ErrorIs(t, err, api.ErrNotFound)
+
+		logs := buf.String()
+		assert.Contains(t, logs, "op=get kind=Pod namespace=audit name=web items=1")
+		assert.Contains(t, logs, "op=get kind=Pod namespace=audit name=gone error_class=not_found items=0")
+		assert.NotContains(t, logs, "super-secret-token")
+	})
+
+	t.Run("impersonated client keeps logger", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+		client, err := NewK8sClient(
+			WithTokenAuth(TokenAuth{Server: server.URL, CAData: caPEM, Token: "super-secret-token"}),
+			WithLogger(logger))
+		require.NoError(t, err)
+		defer client.Close()
+
+		other, err := client.Impersonate(Impersonation{UserName: "jane"})
+		require.NoError(t, err)
+		defer other.Close()
+
+		_, err = other.GetPodAPI().GetPodByName(context.Background(), "audit", "web")
+		require.NoError(t, err)
+
+		assert.Contains(t, buf.String(), "name=web")
+	})
+
+	t.Run("rejects nil logger", func(t *testing.T) {
+		client, err := NewK8sClient(withTestServer(server.URL), WithLogger(nil))
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid logger")
+		assert.Nil(t, client)
+	})
+}
@@ -0,0 +1,261 @@
+package k8sclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	api "github.com/kaudit/k8s_client"
+)
+
+// preflightVerbs returns the verbs the resource APIs use.
+func preflightVerbs() []string {
+	return []string{"get", "list", "watch"}
+}
+
+// preflightResource is a resource exposed by the client.
+type preflightResource struct {
+	group      string
+	resource   string
+	namespaced bool
+}
+
+// preflightResources returns the resources of the four APIs of the client.
+func preflightResources() []preflightResource {
+	return []preflightResource{
+		{resource: "pods", namespaced: true},
+		{resource: "services", namespaced: true},
+		{group: "apps", resource: "deployments", namespaced: true},
+		{resource: "namespaces"},
+	}
+}
+
+// Permission tells whether the credentials of a client may use a verb on a resource.
+type Permission struct {
+	// Resource is the plural resource name, e.g. "pods" or "deployments".
+	Resource string `json:"resource"`
+	Verb     string `json:"verb"`
+	// Namespace is empty for cluster-scoped resources and checks across all namespaces.
+	Namespace string `json:"namespace"`
+	// Reason explains a denial when the API server gives one.
+	Reason  string `json:"reason,omitempty"`
+	Allowed bool   `json:"allowed"`
+}
+
+// String formats the permission for display.
+func (p Permission) String() string {
+	scope := "cluster-wide"
+	if p.Namespace != "" {
+		scope = "in namespace " + p.Namespace
+	}
+
+	return fmt.Sprintf("%s %s %s", p.Verb, p.Resource, scope)
+}
+
+// PermissionMatrix is the result of Preflight, one Permission per resource, verb and namespace.
+type PermissionMatrix []Permission
+
+// Allowed reports whether verb on resource in namespace was found to be allowed.
+// It returns false for combinations that were not checked.
+func (m PermissionMatrix) Allowed(resource, verb, namespace string) bool {
+	return slices.ContainsFunc(m, func(p Permission) bool {
+		return p.Allowed && p.Resource == resource && p.Verb == verb && p.Namespace == namespace
+	})
+}
+
+// Denied returns the permissions that are not allowed.
+func (m PermissionMatrix) Denied() PermissionMatrix {
+	var denied PermissionMatrix
+	for _, p := range m {
+		if !p.Allowed {
+			denied = append(denied, p)
+		}
+	}
+
+	return denied
+}
+
+// Err returns an error matching api.ErrForbidden that lists the denied permissions,
+// or nil if all permissions are allowed.
+func (m PermissionMatrix) Err() error {
+	denied := m.Denied()
+	if len(denied) == 0 {
+		return nil
+	}
+
+	missing := make([]string, 0, len(denied))
+	for _, p := range denied {
+		missing = append(missing, p.String())
+	}
+
+	return fmt.Errorf("%w: missing permissions: %s", api.ErrForbidden, strings.Join(missing, ", "))
+}
+
+// Preflight checks whether the credentials of the client can get, list and watch each resource
+// the client exposes, so an audit can fail early with a clear error instead of halfway through
+// with Forbidden. Pods, services and deployments are checked in each of namespaces, where ""
+// stands for all namespaces; namespaces are checked cluster-wide. Without namespaces, the
+// default namespace of the client is checked.
+//
+// Each namespace is checked with one SelfSubjectRulesReview; permissions the returned rules do
+// not grant, and all cluster-wide permissions, are checked with SelfSubjectAccessReviews.
+//
+// It returns an error if a review cannot be created, e.g. because the reviews are disabled.
+// Denied permissions are not an error; use PermissionMatrix.Err to turn them into one.
+func (k *K8sClient) Preflight(ctx context.Context, namespaces ...string) (PermissionMatrix, error) {
+	if err := validateNamespaces(namespaces); err != nil {
+		return nil, err
+	}
+
+	if len(namespaces) == 0 {
+		namespaces = []string{k.namespace}
+	}
+
+	var matrix PermissionMatrix
+	for _, namespace := range namespaces {
+		permissions, err := k.preflightNamespace(ctx, namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		matrix = append(matrix, permissions...)
+	}
+
+	for _, resource := range preflightResources() {
+		if resource.namespaced {
+			continue
+		}
+
+		for _, verb := range preflightVerbs() {
+			permission, err := k.accessReview(ctx, resource, verb, "")
+			if err != nil {
+				return nil, err
+			}
+
+			matrix = append(matrix, permission)
+		}
+	}
+
+	return matrix, nil
+}
+
+// validateNamespaces checks that namespaces are valid namespace names or empty.
+func validateNamespaces(namespaces []string) error {
+	for _, namespace := range namespaces {
+		if namespace == "" {
+			continue
+		}
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			return fmt.Errorf("invalid namespace %q: %w", namespace, errors.New(strings.Join(errs, "; ")))
+		}
+	}
+
+	return nil
+}
+
+// preflightNamespace checks the namespaced resources in namespace, or in all namespaces if
+// namespace is empty.
+func (k *K8sClient) preflightNamespace(ctx context.Context, namespace string) ([]Permission, error) {
+	var status *authorizationv1.SubjectRulesReviewStatus
+	if namespace != "" {
+		var err error
+		if status, err = k.rulesReview(ctx, namespace); err != nil {
+			return nil, err
+		}
+	}
+
+	var permissions []Permission
+	for _, resource := range preflightResources() {
+		if !resource.namespaced {
+			continue
+		}
+
+		for _, verb := range preflightVerbs() {
+			if status != nil && grants(status.ResourceRules, resource, verb) {
+				permissions = append(permissions,
+					Permission{Resource: resource.resource, Verb: verb, Namespace: namespace, Allowed: true})
+				continue
+			}
+
+			permission, err := k.accessReview(ctx, resource, verb, namespace)
+			if err != nil {
+				return nil, err
+			}
+
+			permissions = append(permissions, permission)
+		}
+	}
+
+	return permissions, nil
+}
+
+// rulesReview returns the rules the credentials of the client have in namespace.
+func (k *K8sClient) rulesReview(ctx context.Context, namespace string) (*authorizationv1.SubjectRulesReviewStatus, error) {
+	review := &authorizationv1.SelfSubjectRulesReview{
+		Spec: authorizationv1.SelfSubjectRulesReviewSpec{Namespace: namespace},
+	}
+
+	result, err := k.clientset.AuthorizationV1().SelfSubjectRulesReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("SelfSubjectRulesReviews.Create failed: %w", err)
+	}
+
+	return &result.Status, nil
+}
+
+// accessReview asks the API server whether the credentials of the client may use verb on
+// resource in namespace.
+func (k *K8sClient) accessReview(
+	ctx context.Context, resource preflightResource, verb, namespace string,
+) (Permission, error) {
+	review := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      verb,
+				Group:     resource.group,
+				Resource:  resource.resource,
+			},
+		},
+	}
+
+	result, err := k.clientset.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return Permission{}, fmt.Errorf("SelfSubjectAccessReviews.Create failed: %w", err)
+	}
+
+	reason := result.Status.Reason
+	if reason == "" {
+		reason = result.Status.EvaluationError
+	}
+
+	return Permission{
+		Resource:  resource.resource,
+		Verb:      verb,
+		Namespace: namespace,
+		Reason:    reason,
+		Allowed:   result.Status.Allowed,
+	}, nil
+}
+
+// grants reports whether rules allow verb on every object of resource.
+// Rules restricted to resource names do not count, as the APIs read whole collections.
+func grants(rules []authorizationv1.ResourceRule, resource preflightResource, verb string) bool {
+	return slices.ContainsFunc(rules, func(rule authorizationv1.ResourceRule) bool {
+		return len(rule.ResourceNames) == 0 &&
+			matches(rule.Verbs, verb) &&
+			matches(rule.APIGroups, resource.group) &&
+			matches(rule.Resources, resource.resource)
+	})
+}
+
+// matches reports whether values contain value or the wildcard "*".
+func matches(values []string, value string) bool {
+	return slices.Contains(values, value) || slices.Contains(values, "*")
+}
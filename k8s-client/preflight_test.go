@@ -0,0 +1,139 @@
+package k8sclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	api "github.com/kaudit/k8s_client"
+)
+
+// preflightClient returns a client whose rules reviews return rules and whose access reviews
+// allow the attributes accepted by allow. Access reviews are recorded in reviews.
+func preflightClient(
+	rules []authorizationv1.ResourceRule,
+	allow func(attrs *authorizationv1.ResourceAttributes) bool,
+	reviews *[]authorizationv1.ResourceAttributes,
+) *K8sClient {
+	clientset := fake.NewClientset()
+
+	clientset.PrependReactor("create", "selfsubjectrulesreviews",
+		func(k8stesting.Action) (bool, runtime.Object, error) {
+			return true, &authorizationv1.SelfSubjectRulesReview{
+				Status: authorizationv1.SubjectRulesReviewStatus{ResourceRules: rules},
+			}, nil
+		})
+
+	clientset.PrependReactor("create", "selfsubjectaccessreviews",
+		func(action k8stesting.Action) (bool, runtime.Object, error) {
+			review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+			attrs := review.Spec.ResourceAttributes
+			*reviews = append(*reviews, *attrs)
+
+			review.Status.Allowed = allow(attrs)
+			if !review.Status.Allowed {
+				review.Status.Reason = "no RBAC policy matched"
+			}
+
+			return true, review, nil
+		})
+
+	return &K8sClient{clientset: clientset, namespace: "audit"}
+}
+
+func TestK8sClient_Preflight(t *testing.T) {
+	t.Run("rules grant namespaced access", func(t *testing.T) {
+		var reviews []authorizationv1.ResourceAttributes
+		client := preflightClient([]authorizationv1.ResourceRule{
+			{Verbs: []string{"get", "list", "watch"}, APIGroups: []string{"", "apps"}, Resources: []string{"*"}},
+		}, func(*authorizationv1.ResourceAttributes) bool { return true }, &reviews)
+
+		matrix, err := client.Preflight(context.Background())
+
+		require.NoError(t, err)
+		require.Len(t, matrix, 12)
+		require.NoError(t, matrix.Err())
+		assert.True(t, matrix.Allowed("deployments", "watch", "audit"))
+		assert.True(t, matrix.Allowed("namespaces", "list", ""))
+		assert.False(t, matrix.Allowed("pods", "list", "other"))
+
+		require.Len(t, reviews, 3, "only cluster-wide namespace access needs access reviews")
+		for _, review := range reviews {
+			assert.Equal(t, "namespaces", review.Resource)
+		}
+	})
+
+	t.Run("access reviews decide what rules do not grant", func(t *testing.T) {
+		var reviews []authorizationv1.ResourceAttributes
+		client := preflightClient([]authorizationv1.ResourceRule{
+			{Verbs: []string{"*"}, APIGroups: []string{""}, Resources: []string{"pods"}},
+			{Verbs: []string{"get"}, APIGroups: []string{""}, Resources: []string{"services"}, ResourceNames: []string{"web"}},
+		}, func(attrs *authorizationv1.ResourceAttributes) bool {
+			return attrs.Resource == "services" && attrs.Verb != "watch"
+		}, &reviews)
+
+		matrix, err := client.Preflight(context.Background(), "team-a")
+
+		require.NoError(t, err)
+		assert.True(t, matrix.Allowed("pods", "watch", "team-a"))
+		assert.True(t, matrix.Allowed("services", "get", "team-a"))
+		assert.False(t, matrix.Allowed("services", "watch", "team-a"))
+		assert.False(t, matrix.Allowed("deployments", "list", "team-a"))
+
+		denied := matrix.Denied()
+		require.Len(t, denied, 7)
+		assert.Equal(t, Permission{
+			Resource: "services", Verb: "watch", Namespace: "team-a", Reason: "no RBAC policy matched",
+		}, denied[0])
+		assert.Equal(t, "apps", reviews[3].Group)
+
+		err = matrix.Err()
+		require.ErrorIs(t, err, api.ErrForbidden)
+		assert.Contains(t, err.Error(), "watch services in namespace team-a")
+		assert.Contains(t, err.Error(), "list namespaces cluster-wide")
+	})
+
+	t.Run("all namespaces use access reviews", func(t *testing.T) {
+		var reviews []authorizationv1.ResourceAttributes
+		client := preflightClient(nil, func(*authorizationv1.ResourceAttributes) bool { return true }, &reviews)
+
+		matrix, err := client.Preflight(context.Background(), "")
+
+		require.NoError(t, err)
+		assert.True(t, matrix.Allowed("pods", "list", ""))
+		assert.Len(t, reviews, 12)
+	})
+
+	t.Run("fails on invalid namespace", func(t *testing.T) {
+		var reviews []authorizationv1.ResourceAttributes
+		client := preflightClient(nil, func(*authorizationv1.ResourceAttributes) bool { return true }, &reviews)
+
+		matrix, err := client.Preflight(context.Background(), "Not_A_Namespace")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid namespace")
+		assert.Nil(t, matrix)
+	})
+
+	t.Run("fails when review cannot be created", func(t *testing.T) {
+		clientset := fake.NewClientset()
+		clientset.PrependReactor("create", "selfsubjectrulesreviews",
+			func(k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.New("reviews disabled")
+			})
+		client := &K8sClient{clientset: clientset, namespace: "audit"}
+
+		matrix, err := client.Preflight(context.Background())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "SelfSubjectRulesReviews.Create failed")
+		assert.Nil(t, matrix)
+	})
+}
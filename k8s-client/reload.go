@@ -0,0 +1,71 @@
+package k8sclient
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kaudit/val"
+	"k8s.io/client-go/rest"
+
+	"github.com/kaudit/k8s_client/internal/connection/reloading"
+)
+
+// reloadConfig holds the settings of credential reloading.
+type reloadConfig struct {
+	interval time.Duration
+}
+
+// WithCredentialReload creates a K8sClientOption that keeps the credentials of the connection
+// current for long-running clients. The connection is established again, e.g. the kubeconfig
+// is reloaded through its K8sAuthLoader, every interval and whenever the API server rejects
+// a request with 401 Unauthorized. Changed credentials are swapped in atomically for all APIs,
+// informers and watches of the client; a request rejected with the old credentials is sent
+// once more with the new ones.
+//
+// An interval of zero reloads only after 401 responses, at most once every 10 seconds.
+// The server of the connection must stay the same; a reload pointing at another server fails
+// and the current credentials stay in use. Close stops the periodic reloads.
+func WithCredentialReload(interval time.Duration) K8sClientOption {
+	return func(k8sClient *K8sClient) error {
+		if err := val.ValidateWithTag(interval, "gte=0"); err != nil {
+			return fmt.Errorf("invalid reload interval: %w", err)
+		}
+
+		k8sClient.reload = &reloadConfig{interval: interval}
+
+		return nil
+	}
+}
+
+// startReload starts reloading the credentials of cfg and returns the config of the clients,
+// which send their requests through the reloader.
+func (k *K8sClient) startReload(cfg *rest.Config) (*rest.Config, error) {
+	var opts []reloading.Option
+	if k.logger != nil {
+		opts = append(opts, reloading.WithLogger(k.logger))
+	}
+
+	reloader, err := reloading.New(cfg, k.reconnect, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("reloading.New failed: %w", err)
+	}
+
+	k.reloader = reloader
+	reloader.Start(k.reload.interval)
+
+	return reloader.ClientConfig(cfg), nil
+}
+
+// reconnect establishes the connection again for a credential reload.
+func (k *K8sClient) reconnect() (*rest.Config, error) {
+	conn, err := k.connect()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = k.applyConfig(conn.config); err != nil {
+		return nil, err
+	}
+
+	return conn.config, nil
+}
@@ -0,0 +1,106 @@
+package k8sclient
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/kaudit/k8s_client"
+	kubeconfigloader "github.com/kaudit/k8s_client/loader/kubeconfig"
+)
+
+func TestWithCredentialReload(t *testing.T) {
+	var mu sync.Mutex
+	token := "auditor-token"
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		ok := r.Header.Get("Authorization") == "Bearer "+token
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Unauthorized","code":401}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"kind":"Pod","apiVersion":"v1","metadata":{"name":"web","namespace":"audit"}}`))
+	}))
+	defer server.Close()
+
+	rotate := func(t *testing.T, path, next string) {
+		t.Helper()
+
+		data := bytes.ReplaceAll(testKubeconfig(server.URL), []byte("auditor-token"), []byte(next))
+		require.NoError(t, os.WriteFile(path, data, 0o600))
+
+		mu.Lock()
+		token = next
+		mu.Unlock()
+	}
+
+	t.Run("reloads kubeconfig after 401", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config")
+		rotate(t, path, "auditor-token")
+
+		client, err := NewK8sClient(WithKubeConfigLoader(kubeconfigloader.NewK8sConfigLoader(path)),
+			WithContext("test"), WithCredentialReload(0))
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.GetPodAPI().GetPodByName(context.Background(), "audit", "web")
+		require.NoError(t, err)
+
+		rotate(t, path, "rotated-token")
+
+		_, err = client.GetPodAPI().GetPodByName(context.Background(), "audit", "web")
+		require.NoError(t, err)
+	})
+
+	t.Run("fails with 401 when the kubeconfig was not rotated", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config")
+		rotate(t, path, "auditor-token")
+
+		client, err := NewK8sClient(WithKubeConfigLoader(kubeconfigloader.NewK8sConfigLoader(path)),
+			WithContext("test"), WithCredentialReload(0))
+		require.NoError(t, err)
+		defer client.Close()
+
+		mu.Lock()
+		token = "revoked"
+		mu.Unlock()
+
+		_, err = client.GetPodAPI().GetPodByName(context.Background(), "audit", "web")
+		require.ErrorIs(t, err, api.ErrUnauthorized)
+	})
+
+	t.Run("stops periodic reloads on close", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config")
+		rotate(t, path, "auditor-token")
+
+		client, err := NewK8sClient(WithKubeConfigLoader(kubeconfigloader.NewK8sConfigLoader(path)),
+			WithContext("test"), WithCredentialReload(time.Millisecond))
+		require.NoError(t, err)
+
+		client.Close()
+		client.Close()
+	})
+
+	t.Run("rejects negative interval", func(t *testing.T) {
+		client, err := NewK8sClient(WithCredentialReload(-time.Second))
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid reload interval")
+		assert.Nil(t, client)
+	})
+}
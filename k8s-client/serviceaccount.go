@@ -0,0 +1,40 @@
+package k8sclient
+
+import (
+	"fmt"
+
+	"github.com/kaudit/val"
+
+	"github.com/kaudit/k8s_client/internal/connection/serviceaccount"
+)
+
+// ServiceAccountOption configures the in-cluster connection of WithServiceAccount.
+type ServiceAccountOption func() (serviceaccount.Option, error)
+
+// WithServiceAccountDir creates a ServiceAccountOption that reads the token, ca.crt and
+// namespace files from dir, e.g. a projected volume mounted at a custom path.
+func WithServiceAccountDir(dir string) ServiceAccountOption {
+	return func() (serviceaccount.Option, error) {
+		if err := val.ValidateWithTag(dir, "required,dir"); err != nil {
+			return nil, fmt.Errorf("invalid service account directory: %w", err)
+		}
+
+		return serviceaccount.WithDir(dir), nil
+	}
+}
+
+// WithServiceAccountAPIServer creates a ServiceAccountOption that connects to host and port
+// instead of the address in the KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT
+// environment variables.
+func WithServiceAccountAPIServer(host, port string) ServiceAccountOption {
+	return func() (serviceaccount.Option, error) {
+		if err := val.ValidateWithTag(host, "required,hostname|ip"); err != nil {
+			return nil, fmt.Errorf("invalid API server host: %w", err)
+		}
+		if err := val.ValidateWithTag(port, "required,numeric"); err != nil {
+			return nil, fmt.Errorf("invalid API server port: %w", err)
+		}
+
+		return serviceaccount.WithAPIServer(host, port), nil
+	}
+}
@@ -0,0 +1,124 @@
+package k8sclient
+
+import (
+	"context"
+	"encoding/pem"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithServiceAccount_Options(t *testing.T) {
+	var authorization string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"kind":"Pod","apiVersion":"v1","metadata":{"name":"web","namespace":"audit"}}`))
+	}))
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	host, port, err := net.SplitHostPort(u.Host)
+	require.NoError(t, err)
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	mount := func(t *testing.T, namespace string) string {
+		t.Helper()
+
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "token"), []byte("sa-token"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "ca.crt"), caPEM, 0o600))
+		if namespace != "" {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "namespace"), []byte(namespace), 0o600))
+		}
+
+		return dir
+	}
+
+	tests := []struct {
+		name          string
+		dir           string
+		options       []K8sClientOption
+		wantNamespace string
+	}{
+		{
+			name:          "Uses pod namespace",
+			dir:           mount(t, "audit"),
+			wantNamespace: "audit",
+		},
+		{
+			name:          "Default namespace option wins",
+			dir:           mount(t, "audit"),
+			options:       []K8sClientOption{WithDefaultNamespace("team-a")},
+			wantNamespace: "team-a",
+		},
+		{
+			name:          "Falls back without namespace file",
+			dir:           mount(t, ""),
+			wantNamespace: DefaultNamespace,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authorization = ""
+
+			options := append([]K8sClientOption{
+				WithServiceAccount(WithServiceAccountDir(tt.dir), WithServiceAccountAPIServer(host, port)),
+			}, tt.options...)
+
+			client, err := NewK8sClient(options...)
+			require.NoError(t, err)
+			defer client.Close()
+
+			_, err = client.GetPodAPI().GetPodByName(context.Background(), "audit", "web")
+			require.NoError(t, err)
+
+			assert.Equal(t, "Bearer sa-token", authorization)
+			assert.Equal(t, tt.wantNamespace, client.DefaultNamespace())
+			assert.Equal(t, ConnectionSourceServiceAccount, client.ConnectionSource())
+		})
+	}
+}
+
+func TestWithServiceAccount_InvalidOptions(t *testing.T) {
+	tests := []struct {
+		name          string
+		option        ServiceAccountOption
+		errorContains string
+	}{
+		{
+			name:          "Missing directory",
+			option:        WithServiceAccountDir(filepath.Join(t.TempDir(), "missing")),
+			errorContains: "invalid service account directory",
+		},
+		{
+			name:          "Invalid host",
+			option:        WithServiceAccountAPIServer("not a host", "443"),
+			errorContains: "invalid API server host",
+		},
+		{
+			name:          "Invalid port",
+			option:        WithServiceAccountAPIServer("10.0.0.1", "https"),
+			errorContains: "invalid API server port",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewK8sClient(WithServiceAccount(tt.option))
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+			assert.Nil(t, client)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package k8sclient
+
+import (
+	"fmt"
+
+	"github.com/kaudit/val"
+	"k8s.io/client-go/rest"
+)
+
+// TokenAuth describes a connection to an API server authenticated with a bearer token.
+//
+// Exactly one of Token and TokenFile must be set. TokenFile is re-read periodically, so
+// tokens rotated on disk are picked up without reconnecting. The server certificate is
+// verified against CAData or CAFile, or against the system roots when both are empty.
+type TokenAuth struct {
+	Server    string `validate:"required,url_prefix,url"`
+	CAData    []byte `validate:"excluded_with=CAFile"`
+	CAFile    string `validate:"omitempty,file"`
+	Token     string `validate:"required_without=TokenFile,excluded_with=TokenFile"`
+	TokenFile string `validate:"omitempty,file"`
+}
+
+// WithTokenAuth creates a K8sClientOption that connects to auth.Server with a bearer token,
+// without a kubeconfig or an in-cluster environment. WithServer replaces auth.Server.
+//
+// This option is mutually exclusive with WithKubeConfigLoader, WithServiceAccount and
+// WithAutoDetect. Applying more than one connection option to the same client results in
+// ErrAlreadyConfigured.
+func WithTokenAuth(auth TokenAuth) K8sClientOption {
+	return func(k8sClient *K8sClient) error {
+		if err := val.ValidateStruct(auth); err != nil {
+			return fmt.Errorf("invalid token auth: %w", err)
+		}
+
+		return k8sClient.setConnector(func() (*connection, error) {
+			if err := k8sClient.requireNoKubeconfigSelection(); err != nil {
+				return nil, err
+			}
+
+			cfg := &rest.Config{
+				Host:            auth.Server,
+				BearerToken:     auth.Token,
+				BearerTokenFile: auth.TokenFile,
+				TLSClientConfig: rest.TLSClientConfig{
+					CAData: auth.CAData,
+					CAFile: auth.CAFile,
+				},
+			}
+
+			if server := k8sClient.overrides.ClusterInfo.Server; server != "" {
+				cfg.Host = server
+			}
+
+			return &connection{config: cfg, source: ConnectionSourceToken}, nil
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package k8sclient
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithTokenAuth(t *testing.T) {
+	var authorization string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"kind":"Pod","apiVersion":"v1","metadata":{"name":"web","namespace":"default"}}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	caFile := filepath.Join(dir, "ca.crt")
+	require.NoError(t, os.WriteFile(caFile, caPEM, 0o600))
+	tokenFile := filepath.Join(dir, "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("file-token\n"), 0o600))
+
+	tests := []struct {
+		name              string
+		auth              TokenAuth
+		options           []K8sClientOption
+		wantAuthorization string
+		errorContains     string
+	}{
+		{
+			name:              "Static token and CA data",
+			auth:              TokenAuth{Server: server.URL, CAData: caPEM, Token: "static-token"},
+			wantAuthorization: "Bearer static-token",
+		},
+		{
+			name:              "Token file and CA file",
+			auth:              TokenAuth{Server: server.URL, CAFile: caFile, TokenFile: tokenFile},
+			wantAuthorization: "Bearer file-token",
+		},
+		{
+			name:          "Untrusted server certificate",
+			auth:          TokenAuth{Server: server.URL, Token: "static-token"},
+			errorContains: "certificate",
+		},
+		{
+			name:          "Missing server",
+			auth:          TokenAuth{Token: "static-token"},
+			errorContains: "invalid token auth",
+		},
+		{
+			name:          "Server without scheme",
+			auth:          TokenAuth{Server: "cluster.example.com", Token: "static-token"},
+			errorContains: "invalid token auth",
+		},
+		{
+			name:          "Missing token",
+			auth:          TokenAuth{Server: server.URL, CAData: caPEM},
+			errorContains: "invalid token auth",
+		},
+		{
+			name:          "Token and token file",
+			auth:          TokenAuth{Server: server.URL, Token: "static-token", TokenFile: tokenFile},
+			errorContains: "invalid token auth",
+		},
+		{
+			name:          "Missing token file",
+			auth:          TokenAuth{Server: server.URL, TokenFile: filepath.Join(dir, "missing")},
+			errorContains: "invalid token auth",
+		},
+		{
+			name:          "CA data and CA file",
+			auth:          TokenAuth{Server: server.URL, CAData: caPEM, CAFile: caFile, Token: "static-token"},
+			errorContains: "invalid token auth",
+		},
+		{
+			name:              "Server override",
+			auth:              TokenAuth{Server: "https://api.cluster.invalid", CAData: caPEM, Token: "static-token"},
+			options:           []K8sClientOption{WithServer(server.URL)},
+			wantAuthorization: "Bearer static-token",
+		},
+		{
+			name:          "Context selection",
+			auth:          TokenAuth{Server: server.URL, CAData: caPEM, Token: "static-token"},
+			options:       []K8sClientOption{WithContext("test")},
+			errorContains: "context and user selection require a kubeconfig connection",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authorization = ""
+
+			client, err := NewK8sClient(append([]K8sClientOption{WithTokenAuth(tt.auth)}, tt.options...)...)
+			if err == nil {
+				defer client.Close()
+				_, err = client.GetPodAPI().GetPodByName(context.Background(), "default", "web")
+			}
+
+			if tt.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAuthorization, authorization)
+			assert.Equal(t, ConnectionSourceToken, client.ConnectionSource())
+			assert.Equal(t, DefaultNamespace, client.DefaultNamespace())
+		})
+	}
+
+	t.Run("conflicts with other connection options", func(t *testing.T) {
+		client, err := NewK8sClient(WithTokenAuth(TokenAuth{Server: server.URL, Token: "static-token"}),
+			WithServiceAccount())
+
+		require.ErrorIs(t, err, ErrAlreadyConfigured)
+		assert.Nil(t, client)
+	})
+}
@@ -0,0 +1,158 @@
+package k8sclient
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/kaudit/val"
+	"k8s.io/client-go/rest"
+)
+
+// WithProxy creates a K8sClientOption that sends all requests through the given HTTP(S) proxy,
+// for example "http://proxy.corp.example:3128". It takes precedence over the proxy
+// environment variables and the proxy-url of a kubeconfig.
+func WithProxy(proxyURL string) K8sClientOption {
+	return func(k8sClient *K8sClient) error {
+		if err := val.ValidateWithTag(proxyURL, "required,url_prefix,url"); err != nil {
+			return fmt.Errorf("invalid proxy url: %w", err)
+		}
+
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			return fmt.Errorf("invalid proxy url: %w", err)
+		}
+
+		k8sClient.configure(func(cfg *rest.Config) error {
+			cfg.Proxy = http.ProxyURL(u)
+			return nil
+		})
+
+		return nil
+	}
+}
+
+// WithCABundle creates a K8sClientOption that trusts the PEM encoded certificates of bundle
+// in addition to the certificate authorities of the selected connection.
+// It is used for clusters whose serving certificate is issued by a private CA. When the
+// connection relies on the system roots, the bundle replaces them as client-go does not
+// combine both.
+func WithCABundle(bundle []byte) K8sClientOption {
+	return func(k8sClient *K8sClient) error {
+		if !x509.NewCertPool().AppendCertsFromPEM(bundle) {
+			return errors.New("invalid CA bundle: no PEM encoded certificate found")
+		}
+
+		k8sClient.configure(func(cfg *rest.Config) error {
+			if len(cfg.CAData) == 0 && cfg.CAFile != "" {
+				data, err := os.ReadFile(cfg.CAFile)
+				if err != nil {
+					return fmt.Errorf("os.ReadFile failed: %w", err)
+				}
+
+				cfg.CAData = data
+			}
+
+			cfg.CAFile = ""
+			cfg.CAData = bytes.Join([][]byte{cfg.CAData, bundle}, []byte("\n"))
+
+			return nil
+		})
+
+		return nil
+	}
+}
+
+// WithTLSServerName creates a K8sClientOption that verifies the API server certificate against
+// serverName instead of the host of the server URL, e.g. when connecting through a tunnel.
+func WithTLSServerName(serverName string) K8sClientOption {
+	return func(k8sClient *K8sClient) error {
+		if err := val.ValidateWithTag(serverName, "required,hostname"); err != nil {
+			return fmt.Errorf("invalid TLS server name: %w", err)
+		}
+
+		k8sClient.configure(func(cfg *rest.Config) error {
+			cfg.ServerName = serverName
+			return nil
+		})
+
+		return nil
+	}
+}
+
+// WithInsecureSkipVerify creates a K8sClientOption that disables verification of the API server
+// certificate. Any certificate authorities of the connection are dropped.
+//
+// This makes the connection vulnerable to interception and must only be used against
+// disposable test clusters. A warning is logged once when the client is built, to the logger
+// of WithLogger if given and to the default logger otherwise.
+func WithInsecureSkipVerify() K8sClientOption {
+	return func(k8sClient *K8sClient) error {
+		k8sClient.insecure = true
+
+		k8sClient.configure(func(cfg *rest.Config) error {
+			cfg.Insecure = true
+			cfg.CAFile = ""
+			cfg.CAData = nil
+
+			return nil
+		})
+
+		return nil
+	}
+}
+
+// warnInsecure logs that certificate verification of the API server at host is disabled.
+func (k *K8sClient) warnInsecure(host string) {
+	logger := k.logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	logger.Warn("TLS certificate verification of the Kubernetes API server is DISABLED; "+
+		"credentials and audit results can be intercepted", "host", host)
+}
+
+// WithClientCertificate creates a K8sClientOption that authenticates with the given PEM encoded
+// client certificate and private key, replacing any client certificate of the connection.
+func WithClientCertificate(certPEM, keyPEM []byte) K8sClientOption {
+	return func(k8sClient *K8sClient) error {
+		if _, err := tls.X509KeyPair(certPEM, keyPEM); err != nil {
+			return fmt.Errorf("invalid client certificate: %w", err)
+		}
+
+		k8sClient.configure(func(cfg *rest.Config) error {
+			cfg.CertFile = ""
+			cfg.KeyFile = ""
+			cfg.CertData = certPEM
+			cfg.KeyData = keyPEM
+
+			return nil
+		})
+
+		return nil
+	}
+}
+
+// WithUserAgent creates a K8sClientOption that sends userAgent with every request, for
+// example "kaudit/1.4.0", so audit traffic can be identified in API server logs.
+func WithUserAgent(userAgent string) K8sClientOption {
+	return func(k8sClient *K8sClient) error {
+		if err := val.ValidateWithTag(userAgent, "required,printascii"); err != nil {
+			return fmt.Errorf("invalid user agent: %w", err)
+		}
+
+		k8sClient.configure(func(cfg *rest.Config) error {
+			cfg.UserAgent = userAgent
+			return nil
+		})
+
+		return nil
+	}
+}
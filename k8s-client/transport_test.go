@@ -0,0 +1,198 @@
+package k8sclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/pem"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+	certutil "k8s.io/client-go/util/cert"
+)
+
+// podHandler answers every request with a pod named web and records the user agent.
+func podHandler(userAgent *string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*userAgent = r.UserAgent()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"kind":"Pod","apiVersion":"v1","metadata":{"name":"web","namespace":"default"}}`))
+	})
+}
+
+func TestTransportOptions(t *testing.T) {
+	certPEM, keyPEM, err := certutil.GenerateSelfSignedCertKey("api.cluster.local", nil, nil)
+	require.NoError(t, err)
+
+	caFile := filepath.Join(t.TempDir(), "ca.crt")
+	require.NoError(t, os.WriteFile(caFile, []byte("existing-ca"), 0o600))
+
+	tests := []struct {
+		name          string
+		option        K8sClientOption
+		check         func(t *testing.T, cfg *rest.Config)
+		errorContains string
+	}{
+		{
+			name:   "CA bundle extends CA file",
+			option: WithCABundle(certPEM),
+			check: func(t *testing.T, cfg *rest.Config) {
+				assert.Empty(t, cfg.CAFile)
+				assert.Equal(t, "existing-ca\n"+string(certPEM), string(cfg.CAData))
+			},
+		},
+		{
+			name:   "TLS server name",
+			option: WithTLSServerName("api.cluster.local"),
+			check: func(t *testing.T, cfg *rest.Config) {
+				assert.Equal(t, "api.cluster.local", cfg.ServerName)
+			},
+		},
+		{
+			name:   "Insecure drops CAs",
+			option: WithInsecureSkipVerify(),
+			check: func(t *testing.T, cfg *rest.Config) {
+				assert.True(t, cfg.Insecure)
+				assert.Empty(t, cfg.CAFile)
+				assert.Empty(t, cfg.CAData)
+			},
+		},
+		{
+			name:   "Client certificate",
+			option: WithClientCertificate(certPEM, keyPEM),
+			check: func(t *testing.T, cfg *rest.Config) {
+				assert.Equal(t, certPEM, cfg.CertData)
+				assert.Equal(t, keyPEM, cfg.KeyData)
+				assert.Empty(t, cfg.CertFile)
+			},
+		},
+		{
+			name:   "User agent",
+			option: WithUserAgent("kaudit/1.0"),
+			check: func(t *testing.T, cfg *rest.Config) {
+				assert.Equal(t, "kaudit/1.0", cfg.UserAgent)
+			},
+		},
+		{
+			name:          "Invalid proxy",
+			option:        WithProxy("corp-proxy:3128"),
+			errorContains: "invalid proxy url",
+		},
+		{
+			name:          "Invalid CA bundle",
+			option:        WithCABundle([]byte("not a certificate")),
+			errorContains: "invalid CA bundle",
+		},
+		{
+			name:          "Invalid server name",
+			option:        WithTLSServerName("bad name"),
+			errorContains: "invalid TLS server name",
+		},
+		{
+			name:          "Mismatched key pair",
+			option:        WithClientCertificate(certPEM, []byte("not a key")),
+			errorContains: "invalid client certificate",
+		},
+		{
+			name:          "Empty user agent",
+			option:        WithUserAgent(""),
+			errorContains: "invalid user agent",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &K8sClient{}
+			err := tt.option(client)
+
+			if tt.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				return
+			}
+
+			require.NoError(t, err)
+			cfg := &rest.Config{
+				TLSClientConfig: rest.TLSClientConfig{CAFile: caFile, CertFile: "client.crt", KeyFile: "client.key"},
+			}
+			require.NoError(t, client.applyConfig(cfg))
+			tt.check(t, cfg)
+		})
+	}
+}
+
+func TestNewK8sClient_Transport(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("trusts private CA and sends user agent", func(t *testing.T) {
+		var userAgent string
+		server := httptest.NewTLSServer(podHandler(&userAgent))
+		defer server.Close()
+
+		caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+		client, err := NewK8sClient(withTestServer(server.URL), WithCABundle(caPEM),
+			WithTLSServerName("example.com"), WithUserAgent("kaudit/1.0"))
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.GetPodAPI().GetPodByName(ctx, "default", "web")
+		require.NoError(t, err)
+		assert.Equal(t, "kaudit/1.0", userAgent)
+	})
+
+	t.Run("rejects unknown CA unless insecure", func(t *testing.T) {
+		var userAgent string
+		server := httptest.NewTLSServer(podHandler(&userAgent))
+		defer server.Close()
+
+		client, err := NewK8sClient(withTestServer(server.URL))
+		require.NoError(t, err)
+		_, err = client.GetPodAPI().GetPodByName(ctx, "default", "web")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "certificate")
+
+		client, err = NewK8sClient(withTestServer(server.URL), WithInsecureSkipVerify())
+		require.NoError(t, err)
+		_, err = client.GetPodAPI().GetPodByName(ctx, "default", "web")
+		require.NoError(t, err)
+	})
+
+	t.Run("warns once about insecure connection", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+		client, err := NewK8sClient(withTestServer("https://api.cluster.invalid"), WithInsecureSkipVerify(),
+			WithCredentialReload(time.Hour), WithLogger(logger))
+		require.NoError(t, err)
+		defer client.Close()
+
+		require.NoError(t, client.reloader.Reload())
+		require.NoError(t, client.reloader.Reload())
+
+		assert.Equal(t, 1, strings.Count(buf.String(), "verification of the Kubernetes API server is DISABLED"))
+		assert.Contains(t, buf.String(), "host=https://api.cluster.invalid")
+	})
+
+	t.Run("routes requests through proxy", func(t *testing.T) {
+		var userAgent string
+		proxy := httptest.NewServer(podHandler(&userAgent))
+		defer proxy.Close()
+
+		client, err := NewK8sClient(withTestServer("http://api.cluster.invalid"), WithProxy(proxy.URL))
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.GetPodAPI().GetPodByName(ctx, "default", "web")
+		require.NoError(t, err)
+		assert.NotEmpty(t, userAgent)
+	})
+}
@@ -0,0 +1,156 @@
+package lint
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"time"
+
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// linter collects the findings of one run.
+type linter struct {
+	now             time.Time
+	expiryWarning   time.Duration
+	allowedCommands []string
+	findings        []Finding
+}
+
+// report records a finding.
+func (l *linter) report(rule Rule, severity Severity, kind, name, message string) {
+	l.findings = append(l.findings, Finding{Rule: rule, Severity: severity, Kind: kind, Name: name, Message: message})
+}
+
+// checkContext checks that the cluster and user of a context exist.
+func (l *linter) checkContext(config *clientcmdapi.Config, name string, context *clientcmdapi.Context) {
+	if _, ok := config.Clusters[context.Cluster]; !ok {
+		l.report(RuleMissingCluster, SeverityError, KindContext, name,
+			fmt.Sprintf("cluster %q is not defined", context.Cluster))
+	}
+
+	if context.AuthInfo == "" {
+		return
+	}
+	if _, ok := config.AuthInfos[context.AuthInfo]; !ok {
+		l.report(RuleMissingUser, SeverityError, KindContext, name,
+			fmt.Sprintf("user %q is not defined", context.AuthInfo))
+	}
+}
+
+// checkCluster checks the TLS settings of a cluster.
+func (l *linter) checkCluster(name string, cluster *clientcmdapi.Cluster) {
+	if cluster.InsecureSkipTLSVerify {
+		l.report(RuleInsecureSkipTLSVerify, SeverityError, KindCluster, name,
+			"server certificate is not verified")
+	}
+
+	if len(cluster.CertificateAuthorityData) > 0 {
+		if _, err := parseCertificate(cluster.CertificateAuthorityData); err != nil {
+			l.report(RuleInvalidCertificate, SeverityError, KindCluster, name,
+				fmt.Sprintf("certificate-authority-data: %v", err))
+		}
+	}
+
+	l.checkFile(KindCluster, name, "certificate-authority", cluster.LocationOfOrigin, cluster.CertificateAuthority)
+}
+
+// checkUser checks the credentials of a user.
+func (l *linter) checkUser(name string, user *clientcmdapi.AuthInfo) {
+	if user.Password != "" {
+		l.report(RulePlaintextPassword, SeverityError, KindUser, name,
+			"basic auth password is stored in plaintext")
+	}
+
+	if len(user.ClientCertificateData) > 0 {
+		l.checkClientCertificate(name, user.ClientCertificateData)
+	}
+
+	l.checkFile(KindUser, name, "client-certificate", user.LocationOfOrigin, user.ClientCertificate)
+	l.checkFile(KindUser, name, "client-key", user.LocationOfOrigin, user.ClientKey)
+	l.checkFile(KindUser, name, "tokenFile", user.LocationOfOrigin, user.TokenFile)
+
+	if user.Exec != nil {
+		l.checkExec(name, user.Exec.Command)
+	}
+}
+
+// checkClientCertificate checks that an embedded client certificate parses and is valid
+// for longer than the expiry warning window.
+func (l *linter) checkClientCertificate(name string, data []byte) {
+	cert, err := parseCertificate(data)
+	if err != nil {
+		l.report(RuleInvalidCertificate, SeverityError, KindUser, name,
+			fmt.Sprintf("client-certificate-data: %v", err))
+		return
+	}
+
+	expiry := cert.NotAfter.UTC().Format(time.RFC3339)
+	switch {
+	case l.now.After(cert.NotAfter):
+		l.report(RuleExpiredCertificate, SeverityError, KindUser, name,
+			fmt.Sprintf("client certificate expired at %s", expiry))
+	case cert.NotAfter.Sub(l.now) < l.expiryWarning:
+		l.report(RuleExpiringCertificate, SeverityWarning, KindUser, name,
+			fmt.Sprintf("client certificate expires at %s", expiry))
+	}
+}
+
+// checkExec checks that the command of an exec plugin is allowed and can be found.
+func (l *linter) checkExec(name, command string) {
+	if l.allowedCommands != nil && !slices.Contains(l.allowedCommands, filepath.Base(command)) {
+		l.report(RuleUnknownExecCommand, SeverityError, KindUser, name,
+			fmt.Sprintf("exec command %q is not allowed", command))
+		return
+	}
+
+	if _, err := exec.LookPath(command); err != nil {
+		l.report(RuleUnknownExecCommand, SeverityError, KindUser, name,
+			fmt.Sprintf("exec command %q not found", command))
+	}
+}
+
+// checkFile checks that a referenced file can be opened for reading.
+func (l *linter) checkFile(kind, name, field, origin, path string) {
+	if path == "" {
+		return
+	}
+
+	if !filepath.IsAbs(path) && origin != "" {
+		path = filepath.Join(filepath.Dir(origin), path)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		l.report(RuleUnreachableFile, SeverityError, kind, name, fmt.Sprintf("%s: %v", field, err))
+		return
+	}
+	_ = f.Close()
+}
+
+// parseCertificate parses the first PEM encoded certificate of data.
+func parseCertificate(data []byte) (*x509.Certificate, error) {
+	for rest := data; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return nil, errors.New("no PEM encoded certificate found")
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("x509.ParseCertificate failed: %w", err)
+		}
+
+		return cert, nil
+	}
+}
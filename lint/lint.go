@@ -0,0 +1,161 @@
+// Package lint checks kubeconfigs for broken references and dangerous settings before they
+// are used to connect. Findings are structured, so a CLI can print them and CI can refuse
+// kubeconfigs with findings of SeverityError.
+package lint
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	api "github.com/kaudit/k8s_client"
+)
+
+// Severity tells how serious a finding is.
+type Severity string
+
+const (
+	// SeverityError marks a kubeconfig that is broken or unsafe to use.
+	SeverityError Severity = "error"
+	// SeverityWarning marks a kubeconfig that works but needs attention soon.
+	SeverityWarning Severity = "warning"
+)
+
+// Rule identifies the check that produced a finding.
+type Rule string
+
+const (
+	// RuleMissingCurrentContext reports a current-context that names no context.
+	RuleMissingCurrentContext Rule = "missing-current-context"
+	// RuleMissingCluster reports a context referencing an undefined cluster.
+	RuleMissingCluster Rule = "missing-cluster"
+	// RuleMissingUser reports a context referencing an undefined user.
+	RuleMissingUser Rule = "missing-user"
+	// RuleInsecureSkipTLSVerify reports a cluster whose certificate is not verified.
+	RuleInsecureSkipTLSVerify Rule = "insecure-skip-tls-verify"
+	// RulePlaintextPassword reports a user with a basic auth password in the kubeconfig.
+	RulePlaintextPassword Rule = "plaintext-password"
+	// RuleInvalidCertificate reports embedded certificate data that does not parse.
+	RuleInvalidCertificate Rule = "invalid-certificate"
+	// RuleExpiredCertificate reports an embedded client certificate past its expiry.
+	RuleExpiredCertificate Rule = "expired-certificate"
+	// RuleExpiringCertificate reports an embedded client certificate expiring soon.
+	RuleExpiringCertificate Rule = "expiring-certificate"
+	// RuleUnknownExecCommand reports an exec plugin whose command is not found or not allowed.
+	RuleUnknownExecCommand Rule = "unknown-exec-command"
+	// RuleUnreachableFile reports a referenced file that cannot be read.
+	RuleUnreachableFile Rule = "unreachable-file"
+)
+
+// Kinds of kubeconfig entries findings are reported for.
+const (
+	KindContext = "context"
+	KindCluster = "cluster"
+	KindUser    = "user"
+)
+
+// DefaultExpiryWarning is how long before expiry an embedded client certificate is reported
+// with RuleExpiringCertificate.
+const DefaultExpiryWarning = 7 * 24 * time.Hour
+
+// Finding is a problem found in a kubeconfig.
+type Finding struct {
+	Rule     Rule     `json:"rule"`
+	Severity Severity `json:"severity"`
+	// Kind is the kind of kubeconfig entry the finding is about, one of the Kind constants.
+	Kind    string `json:"kind"`
+	Name    string `json:"name"`
+	Message string `json:"message"`
+}
+
+// String formats the finding for display.
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s %q: %s (%s)", f.Severity, f.Kind, f.Name, f.Message, f.Rule)
+}
+
+// HasErrors reports whether any of findings has SeverityError.
+func HasErrors(findings []Finding) bool {
+	return slices.ContainsFunc(findings, func(f Finding) bool { return f.Severity == SeverityError })
+}
+
+// Option configures the checks.
+type Option func(*linter)
+
+// WithExpiryWarning sets how long before expiry a client certificate is reported as expiring.
+// It defaults to DefaultExpiryWarning.
+func WithExpiryWarning(window time.Duration) Option {
+	return func(l *linter) {
+		l.expiryWarning = window
+	}
+}
+
+// WithAllowedExecCommands restricts exec plugins to the given commands, compared by base name,
+// e.g. "aws", "gke-gcloud-auth-plugin" or "kubelogin". Without it, any command found on the
+// PATH is accepted.
+func WithAllowedExecCommands(commands ...string) Option {
+	return func(l *linter) {
+		l.allowedCommands = commands
+	}
+}
+
+// Kubeconfig loads the kubeconfig from loader and checks it.
+// It returns the findings ordered by kind and name, or an error if the kubeconfig cannot be
+// loaded or parsed.
+func Kubeconfig(loader api.K8sAuthLoader, opts ...Option) ([]Finding, error) {
+	data, err := loader.Load()
+	if err != nil {
+		return nil, fmt.Errorf("loader.Load failed: %w", err)
+	}
+
+	config, err := clientcmd.Load(data)
+	if err != nil {
+		return nil, fmt.Errorf("clientcmd.Load failed: %w", err)
+	}
+
+	return Config(config, opts...), nil
+}
+
+// Config checks a parsed kubeconfig and returns the findings ordered by kind and name.
+//
+// Relative file references are resolved against the directory of the file they were loaded
+// from, or against the working directory for kubeconfigs parsed from bytes.
+func Config(config *clientcmdapi.Config, opts ...Option) []Finding {
+	l := &linter{now: time.Now(), expiryWarning: DefaultExpiryWarning}
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	if config.CurrentContext != "" {
+		if _, ok := config.Contexts[config.CurrentContext]; !ok {
+			l.report(RuleMissingCurrentContext, SeverityError, KindContext, config.CurrentContext,
+				"current-context names no defined context")
+		}
+	}
+
+	for _, name := range sortedKeys(config.Contexts) {
+		l.checkContext(config, name, config.Contexts[name])
+	}
+	for _, name := range sortedKeys(config.Clusters) {
+		l.checkCluster(name, config.Clusters[name])
+	}
+	for _, name := range sortedKeys(config.AuthInfos) {
+		l.checkUser(name, config.AuthInfos[name])
+	}
+
+	return l.findings
+}
+
+// sortedKeys returns the keys of m in ascending order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,237 @@
+package lint
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	mocksauth "github.com/kaudit/k8s_client/mocks/K8sAuthLoader"
+)
+
+// certificate returns a PEM encoded self-signed certificate expiring at notAfter.
+func certificate(t *testing.T, notAfter time.Time) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "auditor"},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestConfig(t *testing.T) {
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.crt")
+	require.NoError(t, os.WriteFile(caFile, []byte("ca"), 0o600))
+
+	valid := certificate(t, time.Now().Add(365*24*time.Hour))
+
+	tests := []struct {
+		name   string
+		config *clientcmdapi.Config
+		opts   []Option
+		want   []Finding
+	}{
+		{
+			name: "Clean kubeconfig",
+			config: &clientcmdapi.Config{
+				CurrentContext: "prod",
+				Contexts:       map[string]*clientcmdapi.Context{"prod": {Cluster: "prod", AuthInfo: "auditor"}},
+				Clusters: map[string]*clientcmdapi.Cluster{
+					"prod": {Server: "https://prod.example.com", CertificateAuthority: caFile},
+				},
+				AuthInfos: map[string]*clientcmdapi.AuthInfo{
+					"auditor": {ClientCertificateData: valid, Exec: &clientcmdapi.ExecConfig{Command: "sh"}},
+				},
+			},
+		},
+		{
+			name: "Broken references",
+			config: &clientcmdapi.Config{
+				CurrentContext: "missing",
+				Contexts:       map[string]*clientcmdapi.Context{"prod": {Cluster: "gone", AuthInfo: "nobody"}},
+			},
+			want: []Finding{
+				{Rule: RuleMissingCurrentContext, Severity: SeverityError, Kind: KindContext, Name: "missing",
+					Message: "current-context names no defined context"},
+				{Rule: RuleMissingCluster, Severity: SeverityError, Kind: KindContext, Name: "prod",
+					Message: `cluster "gone" is not defined`},
+				{Rule: RuleMissingUser, Severity: SeverityError, Kind: KindContext, Name: "prod",
+					Message: `user "nobody" is not defined`},
+			},
+		},
+		{
+			name: "Dangerous cluster and user settings",
+			config: &clientcmdapi.Config{
+				Clusters: map[string]*clientcmdapi.Cluster{
+					"prod": {InsecureSkipTLSVerify: true, CertificateAuthorityData: []byte("garbage")},
+				},
+				AuthInfos: map[string]*clientcmdapi.AuthInfo{
+					"admin": {Username: "admin", Password: "secret"},
+				},
+			},
+			want: []Finding{
+				{Rule: RuleInsecureSkipTLSVerify, Severity: SeverityError, Kind: KindCluster, Name: "prod",
+					Message: "server certificate is not verified"},
+				{Rule: RuleInvalidCertificate, Severity: SeverityError, Kind: KindCluster, Name: "prod",
+					Message: "certificate-authority-data: no PEM encoded certificate found"},
+				{Rule: RulePlaintextPassword, Severity: SeverityError, Kind: KindUser, Name: "admin",
+					Message: "basic auth password is stored in plaintext"},
+			},
+		},
+		{
+			name: "Expired and expiring client certificates",
+			config: &clientcmdapi.Config{
+				AuthInfos: map[string]*clientcmdapi.AuthInfo{
+					"expired":  {ClientCertificateData: certificate(t, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))},
+					"expiring": {ClientCertificateData: certificate(t, time.Now().Add(48*time.Hour).Truncate(time.Second))},
+				},
+			},
+			want: []Finding{
+				{Rule: RuleExpiredCertificate, Severity: SeverityError, Kind: KindUser, Name: "expired",
+					Message: "client certificate expired at 2020-01-01T00:00:00Z"},
+				{Rule: RuleExpiringCertificate, Severity: SeverityWarning, Kind: KindUser, Name: "expiring"},
+			},
+		},
+		{
+			name: "Shorter expiry warning",
+			config: &clientcmdapi.Config{
+				AuthInfos: map[string]*clientcmdapi.AuthInfo{
+					"expiring": {ClientCertificateData: certificate(t, time.Now().Add(48*time.Hour))},
+				},
+			},
+			opts: []Option{WithExpiryWarning(24 * time.Hour)},
+		},
+		{
+			name: "Unknown and disallowed exec commands",
+			config: &clientcmdapi.Config{
+				AuthInfos: map[string]*clientcmdapi.AuthInfo{
+					"missing":    {Exec: &clientcmdapi.ExecConfig{Command: "kaudit-no-such-plugin"}},
+					"disallowed": {Exec: &clientcmdapi.ExecConfig{Command: "/usr/bin/curl"}},
+					"allowed":    {Exec: &clientcmdapi.ExecConfig{Command: "sh"}},
+				},
+			},
+			opts: []Option{WithAllowedExecCommands("sh", "kaudit-no-such-plugin")},
+			want: []Finding{
+				{Rule: RuleUnknownExecCommand, Severity: SeverityError, Kind: KindUser, Name: "disallowed",
+					Message: `exec command "/usr/bin/curl" is not allowed`},
+				{Rule: RuleUnknownExecCommand, Severity: SeverityError, Kind: KindUser, Name: "missing",
+					Message: `exec command "kaudit-no-such-plugin" not found`},
+			},
+		},
+		{
+			name: "Unreachable files resolved against origin",
+			config: &clientcmdapi.Config{
+				Clusters: map[string]*clientcmdapi.Cluster{
+					"relative": {LocationOfOrigin: filepath.Join(dir, "config"), CertificateAuthority: "ca.crt"},
+				},
+				AuthInfos: map[string]*clientcmdapi.AuthInfo{
+					"auditor": {ClientCertificate: filepath.Join(dir, "missing.crt"), ClientKey: filepath.Join(dir, "missing.key")},
+				},
+			},
+			want: []Finding{
+				{Rule: RuleUnreachableFile, Severity: SeverityError, Kind: KindUser, Name: "auditor"},
+				{Rule: RuleUnreachableFile, Severity: SeverityError, Kind: KindUser, Name: "auditor"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := Config(tt.config, tt.opts...)
+
+			require.Len(t, findings, len(tt.want), "%v", findings)
+			for i, want := range tt.want {
+				assert.Equal(t, want.Rule, findings[i].Rule)
+				assert.Equal(t, want.Severity, findings[i].Severity)
+				assert.Equal(t, want.Kind, findings[i].Kind)
+				assert.Equal(t, want.Name, findings[i].Name)
+				if want.Message != "" {
+					assert.Equal(t, want.Message, findings[i].Message)
+				}
+			}
+		})
+	}
+}
+
+func TestKubeconfig(t *testing.T) {
+	t.Run("lints loaded kubeconfig", func(t *testing.T) {
+		loader := &mocksauth.MockK8sAuthLoader{}
+		loader.On("Load").Return([]byte(`
+apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://prod.example.com
+    insecure-skip-tls-verify: true
+  name: prod
+contexts:
+- context:
+    cluster: prod
+    user: auditor
+  name: prod
+current-context: prod
+users:
+- name: auditor
+  user:
+    token: auditor-token
+`), nil)
+
+		findings, err := Kubeconfig(loader)
+
+		require.NoError(t, err)
+		require.Len(t, findings, 1)
+		assert.Equal(t, RuleInsecureSkipTLSVerify, findings[0].Rule)
+		assert.True(t, HasErrors(findings))
+		assert.Equal(t, `error: cluster "prod": server certificate is not verified (insecure-skip-tls-verify)`,
+			findings[0].String())
+	})
+
+	t.Run("fails on load error", func(t *testing.T) {
+		loader := &mocksauth.MockK8sAuthLoader{}
+		loader.On("Load").Return(nil, assert.AnError)
+
+		findings, err := Kubeconfig(loader)
+
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, findings)
+	})
+
+	t.Run("fails on invalid kubeconfig", func(t *testing.T) {
+		loader := &mocksauth.MockK8sAuthLoader{}
+		loader.On("Load").Return([]byte("not a kubeconfig"), nil)
+
+		findings, err := Kubeconfig(loader)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "clientcmd.Load failed")
+		assert.Nil(t, findings)
+	})
+}
+
+func TestHasErrors(t *testing.T) {
+	assert.False(t, HasErrors(nil))
+	assert.False(t, HasErrors([]Finding{{Severity: SeverityWarning}}))
+	assert.True(t, HasErrors([]Finding{{Severity: SeverityWarning}, {Severity: SeverityError}}))
+}
@@ -0,0 +1,266 @@
+// Package encrypted keeps kubeconfigs encrypted at rest.
+//
+// Files are sealed with AES-256-GCM, either under a random key (see GenerateKey) or under a key
+// derived from a passphrase with scrypt. The header of the file, which records the key kind and
+// the scrypt salt, is authenticated together with the kubeconfig, so any tampering is detected.
+package encrypted
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/crypto/scrypt"
+
+	api "github.com/kaudit/k8s_client"
+	"github.com/kaudit/k8s_client/loader/kubeconfig"
+)
+
+var (
+	// ErrNotEncrypted is returned when data lacks the header of an encrypted kubeconfig.
+	ErrNotEncrypted = errors.New("data is not an encrypted kubeconfig")
+	// ErrDecrypt is returned when the key does not match or the data was modified.
+	ErrDecrypt = errors.New("decryption failed: wrong key or corrupted data")
+)
+
+const (
+	// magic starts every encrypted kubeconfig and versions the format.
+	magic = "KAUDENC1"
+	// saltSize is the size of the random scrypt salt of passphrase encrypted files.
+	saltSize = 16
+	// scryptLogN is the log2 of the scrypt cost parameter N used for new files.
+	scryptLogN = 15
+	// maxScryptLogN bounds the cost accepted from a file header, so a crafted file cannot
+	// make Load allocate gigabytes.
+	maxScryptLogN = 20
+	scryptR       = 8
+	scryptP       = 1
+)
+
+// EncryptedLoader implements the auth.K8sAuthLoader interface.
+// It decrypts the kubeconfig returned by another loader, typically a file loader.
+type EncryptedLoader struct {
+	source api.K8sAuthLoader
+	key    KeySource
+}
+
+// NewEncryptedLoader returns a new instance of EncryptedLoader decrypting the data of source
+// with key. The result can be passed to any option accepting a K8sAuthLoader.
+func NewEncryptedLoader(source api.K8sAuthLoader, key KeySource) api.K8sAuthLoader {
+	return &EncryptedLoader{source: source, key: key}
+}
+
+// Load loads the encrypted kubeconfig from the source and returns it decrypted.
+// It returns an error if loading fails, the key cannot be read or does not match, or the
+// decrypted data does not parse as a kubeconfig.
+func (e *EncryptedLoader) Load() ([]byte, error) {
+	if e.source == nil {
+		return nil, errors.New("source loader must not be nil")
+	}
+
+	data, err := e.source.Load()
+	if err != nil {
+		return nil, fmt.Errorf("source.Load failed: %w", err)
+	}
+
+	return Decrypt(data, e.key)
+}
+
+// Encrypt seals kubeconfig with the secret of key.
+// It returns an error if kubeconfig does not parse as a kubeconfig or the key cannot be read.
+func Encrypt(kubeconfigData []byte, key KeySource) ([]byte, error) {
+	if _, err := kubeconfig.NewBytesLoader(kubeconfigData).Load(); err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig: %w", err)
+	}
+
+	secret, err := key.secret()
+	if err != nil {
+		return nil, err
+	}
+
+	header := append([]byte(magic), byte(key.kind))
+	if key.kind == kindPassphrase {
+		var salt []byte
+		if salt, err = random(saltSize); err != nil {
+			return nil, err
+		}
+
+		header = append(append(header, scryptLogN), salt...)
+		if secret, err = derive(secret, salt, scryptLogN); err != nil {
+			return nil, err
+		}
+	}
+
+	aead, err := newAEAD(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := random(aead.NonceSize())
+	if err != nil {
+		return nil, err
+	}
+	header = append(header, nonce...)
+
+	return aead.Seal(bytes.Clone(header), nonce, kubeconfigData, header), nil
+}
+
+// Decrypt opens data sealed by Encrypt with the secret of key.
+// It returns ErrNotEncrypted for data without the encryption header, an error wrapping
+// ErrDecrypt if the key does not match or data was modified, and an error if the decrypted
+// data does not parse as a kubeconfig.
+func Decrypt(data []byte, key KeySource) ([]byte, error) {
+	secret, headerSize, err := openHeader(data, key)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	headerSize += aead.NonceSize()
+	if len(data) < headerSize+aead.Overhead() {
+		return nil, fmt.Errorf("%w: data truncated", ErrDecrypt)
+	}
+
+	header := data[:headerSize]
+	plaintext, err := aead.Open(nil, header[headerSize-aead.NonceSize():], data[headerSize:], header)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+
+	if _, err = kubeconfig.NewBytesLoader(plaintext).Load(); err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig: %w", err)
+	}
+
+	return plaintext, nil
+}
+
+// EncryptFile encrypts the kubeconfig at src with key and writes the result to dst with
+// permissions 0600. It refuses to overwrite an existing dst. The plaintext src is left in
+// place and should be removed once the encrypted copy is verified.
+func EncryptFile(src, dst string, key KeySource) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return fmt.Errorf("os.ReadFile failed: %w", err)
+	}
+
+	sealed, err := Encrypt(data, key)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return fmt.Errorf("os.OpenFile failed: %w", err)
+	}
+
+	if _, err = f.Write(sealed); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("f.Write failed: %w", err)
+	}
+
+	if err = f.Close(); err != nil {
+		return fmt.Errorf("f.Close failed: %w", err)
+	}
+
+	return nil
+}
+
+// String returns a human readable name of the key kind for error messages.
+func (k keyKind) String() string {
+	switch k {
+	case kindKey:
+		return "key"
+	case kindPassphrase:
+		return "passphrase"
+	default:
+		return fmt.Sprintf("unknown key kind %d", byte(k))
+	}
+}
+
+// openHeader checks the header of data against key and returns the secret data is sealed with,
+// together with the size of the header up to the nonce.
+func openHeader(data []byte, key KeySource) ([]byte, int, error) {
+	if !bytes.HasPrefix(data, []byte(magic)) || len(data) <= len(magic) {
+		return nil, 0, ErrNotEncrypted
+	}
+
+	if kind := keyKind(data[len(magic)]); kind != key.kind {
+		return nil, 0, fmt.Errorf("kubeconfig is encrypted with a %s, got a %s", kind, key.kind)
+	}
+
+	secret, err := key.secret()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if key.kind == kindPassphrase {
+		return deriveFromHeader(data, secret)
+	}
+
+	return secret, len(magic) + 1, nil
+}
+
+// deriveFromHeader derives the key of a passphrase encrypted file from the scrypt parameters
+// in its header. It returns the key and the size of the header up to the nonce.
+func deriveFromHeader(data, passphrase []byte) ([]byte, int, error) {
+	offset := len(magic) + 1
+	if len(data) < offset+1+saltSize {
+		return nil, 0, fmt.Errorf("%w: data truncated", ErrDecrypt)
+	}
+
+	logN := data[offset]
+	if logN == 0 || logN > maxScryptLogN {
+		return nil, 0, fmt.Errorf("%w: invalid scrypt cost %d", ErrDecrypt, logN)
+	}
+
+	salt := data[offset+1 : offset+1+saltSize]
+	key, err := derive(passphrase, salt, logN)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return key, offset + 1 + saltSize, nil
+}
+
+// derive derives an AES-256 key from passphrase with scrypt.
+func derive(passphrase, salt []byte, logN byte) ([]byte, error) {
+	key, err := scrypt.Key(passphrase, salt, 1<<logN, scryptR, scryptP, KeySize)
+	if err != nil {
+		return nil, fmt.Errorf("scrypt.Key failed: %w", err)
+	}
+
+	return key, nil
+}
+
+// newAEAD returns AES-GCM keyed with key.
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("aes.NewCipher failed: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("cipher.NewGCM failed: %w", err)
+	}
+
+	return aead, nil
+}
+
+// random returns n random bytes.
+func random(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return nil, fmt.Errorf("rand.Read failed: %w", err)
+	}
+
+	return b, nil
+}
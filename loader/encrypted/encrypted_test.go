@@ -0,0 +1,210 @@
+package encrypted
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kaudit/k8s_client/loader/kubeconfig"
+)
+
+const plainKubeconfig = `
+apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://cluster.example.com
+  name: cluster
+contexts:
+- context:
+    cluster: cluster
+    user: auditor
+  name: cluster
+current-context: cluster
+users:
+- name: auditor
+  user:
+    token: auditor-token
+`
+
+// keyEnv returns a KeySource of a freshly generated key stored in the environment variable name.
+func keyEnv(t *testing.T, name string) KeySource {
+	t.Helper()
+
+	key, err := GenerateKey()
+	require.NoError(t, err)
+	t.Setenv(name, key)
+
+	return KeyEnv(name)
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	t.Setenv("KAUDIT_PASSPHRASE", "correct horse battery staple")
+	t.Setenv("KAUDIT_OTHER_PASSPHRASE", "wrong")
+
+	key := keyEnv(t, "KAUDIT_KEY")
+	otherKey := keyEnv(t, "KAUDIT_OTHER_KEY")
+	passphrase := PassphraseEnv("KAUDIT_PASSPHRASE")
+
+	tests := []struct {
+		name          string
+		encryptWith   KeySource
+		decryptWith   KeySource
+		tamper        func(data []byte) []byte
+		wantErrIs     error
+		errorContains string
+	}{
+		{
+			name:        "Key",
+			encryptWith: key,
+			decryptWith: key,
+		},
+		{
+			name:        "Passphrase",
+			encryptWith: passphrase,
+			decryptWith: passphrase,
+		},
+		{
+			name:        "Wrong key",
+			encryptWith: key,
+			decryptWith: otherKey,
+			wantErrIs:   ErrDecrypt,
+		},
+		{
+			name:        "Wrong passphrase",
+			encryptWith: passphrase,
+			decryptWith: PassphraseEnv("KAUDIT_OTHER_PASSPHRASE"),
+			wantErrIs:   ErrDecrypt,
+		},
+		{
+			name:          "Passphrase for key encrypted data",
+			encryptWith:   key,
+			decryptWith:   passphrase,
+			errorContains: "kubeconfig is encrypted with a key, got a passphrase",
+		},
+		{
+			name:        "Modified ciphertext",
+			encryptWith: key,
+			decryptWith: key,
+			tamper: func(data []byte) []byte {
+				data[len(data)-1] ^= 0xff
+				return data
+			},
+			wantErrIs: ErrDecrypt,
+		},
+		{
+			name:        "Modified scrypt cost",
+			encryptWith: passphrase,
+			decryptWith: passphrase,
+			tamper: func(data []byte) []byte {
+				data[len(magic)+1] = 30
+				return data
+			},
+			wantErrIs: ErrDecrypt,
+		},
+		{
+			name:        "Truncated data",
+			encryptWith: key,
+			decryptWith: key,
+			tamper: func(data []byte) []byte {
+				return data[:len(magic)+4]
+			},
+			wantErrIs: ErrDecrypt,
+		},
+		{
+			name:        "Plaintext kubeconfig",
+			encryptWith: key,
+			decryptWith: key,
+			tamper: func([]byte) []byte {
+				return []byte(plainKubeconfig)
+			},
+			wantErrIs: ErrNotEncrypted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sealed, err := Encrypt([]byte(plainKubeconfig), tt.encryptWith)
+			require.NoError(t, err)
+			assert.NotContains(t, string(sealed), "auditor-token")
+
+			if tt.tamper != nil {
+				sealed = tt.tamper(sealed)
+			}
+
+			plain, err := Decrypt(sealed, tt.decryptWith)
+
+			if tt.wantErrIs != nil || tt.errorContains != "" {
+				require.Error(t, err)
+				if tt.wantErrIs != nil {
+					assert.ErrorIs(t, err, tt.wantErrIs)
+				}
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, plain)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, plainKubeconfig, string(plain))
+		})
+	}
+}
+
+func TestEncrypt_InvalidInput(t *testing.T) {
+	key := keyEnv(t, "KAUDIT_KEY")
+
+	_, err := Encrypt([]byte("not a kubeconfig"), key)
+	assert.ErrorContains(t, err, "invalid kubeconfig")
+
+	_, err = Encrypt([]byte(plainKubeconfig), KeySource{})
+	assert.ErrorContains(t, err, "key source not configured")
+}
+
+func TestEncryptFile_EncryptedLoader(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "config")
+	dst := filepath.Join(dir, "config.enc")
+	keyPath := filepath.Join(dir, "key")
+
+	require.NoError(t, os.WriteFile(src, []byte(plainKubeconfig), 0o600))
+	key, err := GenerateKey()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(keyPath, []byte(key+"\n"), 0o600))
+
+	require.NoError(t, EncryptFile(src, dst, KeyFile(keyPath)))
+
+	info, err := os.Stat(dst)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	t.Run("refuses to overwrite", func(t *testing.T) {
+		err := EncryptFile(src, dst, KeyFile(keyPath))
+		assert.ErrorContains(t, err, "os.OpenFile failed")
+	})
+
+	t.Run("loads decrypted kubeconfig", func(t *testing.T) {
+		l := NewEncryptedLoader(kubeconfig.NewK8sConfigLoader(dst), KeyFile(keyPath))
+
+		data, err := l.Load()
+		require.NoError(t, err)
+		assert.Equal(t, plainKubeconfig, string(data))
+	})
+
+	t.Run("fails when source fails", func(t *testing.T) {
+		l := NewEncryptedLoader(kubeconfig.NewK8sConfigLoader(filepath.Join(dir, "missing")), KeyFile(keyPath))
+
+		data, err := l.Load()
+		assert.ErrorContains(t, err, "source.Load failed")
+		assert.Nil(t, data)
+	})
+
+	t.Run("fails without source", func(t *testing.T) {
+		data, err := NewEncryptedLoader(nil, KeyFile(keyPath)).Load()
+
+		assert.ErrorContains(t, err, "source loader must not be nil")
+		assert.Nil(t, data)
+	})
+}
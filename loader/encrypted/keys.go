@@ -0,0 +1,129 @@
+package encrypted
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kaudit/val"
+)
+
+// KeySize is the size in bytes of the AES-256 keys supplied by KeyFile and KeyEnv.
+const KeySize = 32
+
+// keyKind tells how the secret of a KeySource turns into an encryption key.
+type keyKind byte
+
+const (
+	// kindKey is a base64 encoded AES-256 key used as is.
+	kindKey keyKind = 1
+	// kindPassphrase is a passphrase the key is derived from with scrypt.
+	kindPassphrase keyKind = 2
+)
+
+// KeySource supplies the secret an encrypted kubeconfig is protected with.
+// The secret is read on every use, so it never stays in memory longer than needed.
+type KeySource struct {
+	kind keyKind
+	name string
+	read func() ([]byte, error)
+}
+
+// KeyFile returns a KeySource reading a base64 encoded AES-256 key, as produced by
+// GenerateKey, from the file at path.
+func KeyFile(path string) KeySource {
+	return KeySource{kind: kindKey, name: "key file " + path, read: fileReader(path)}
+}
+
+// KeyEnv returns a KeySource reading a base64 encoded AES-256 key, as produced by
+// GenerateKey, from the environment variable name.
+func KeyEnv(name string) KeySource {
+	return KeySource{kind: kindKey, name: "key environment variable " + name, read: envReader(name)}
+}
+
+// PassphraseFile returns a KeySource reading a passphrase from the file at path.
+// A trailing line break is ignored.
+func PassphraseFile(path string) KeySource {
+	return KeySource{kind: kindPassphrase, name: "passphrase file " + path, read: fileReader(path)}
+}
+
+// PassphraseEnv returns a KeySource reading a passphrase from the environment variable name.
+func PassphraseEnv(name string) KeySource {
+	return KeySource{kind: kindPassphrase, name: "passphrase environment variable " + name, read: envReader(name)}
+}
+
+// GenerateKey returns a new random AES-256 key encoded for KeyFile and KeyEnv.
+func GenerateKey() (string, error) {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("rand.Read failed: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// secret reads the secret of the source and, for keys, decodes it.
+func (k KeySource) secret() ([]byte, error) {
+	if k.read == nil {
+		return nil, errors.New("key source not configured")
+	}
+
+	secret, err := k.read()
+	if err != nil {
+		return nil, err
+	}
+
+	secret = []byte(strings.TrimRight(string(secret), "\r\n"))
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("%s is empty", k.name)
+	}
+
+	if k.kind == kindPassphrase {
+		return secret, nil
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(secret)))
+	if err != nil {
+		return nil, fmt.Errorf("base64 decoding of %s failed: %w", k.name, err)
+	}
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("%s holds a %d byte key, want %d bytes", k.name, len(key), KeySize)
+	}
+
+	return key, nil
+}
+
+// fileReader returns a function reading the file at path.
+func fileReader(path string) func() ([]byte, error) {
+	return func() ([]byte, error) {
+		if err := val.ValidateWithTag(path, "required,file"); err != nil {
+			return nil, fmt.Errorf("val.ValidateWithTag failed: %w", err)
+		}
+
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("os.ReadFile failed: %w", err)
+		}
+
+		return b, nil
+	}
+}
+
+// envReader returns a function reading the environment variable name.
+func envReader(name string) func() ([]byte, error) {
+	return func() ([]byte, error) {
+		if err := val.ValidateWithTag(name, "required"); err != nil {
+			return nil, fmt.Errorf("val.ValidateWithTag failed: %w", err)
+		}
+
+		value := os.Getenv(name)
+		if value == "" {
+			return nil, fmt.Errorf("environment variable %q is not set", name)
+		}
+
+		return []byte(value), nil
+	}
+}
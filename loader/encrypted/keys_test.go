@@ -0,0 +1,119 @@
+package encrypted
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeySource_Secret(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	key, err := GenerateKey()
+	require.NoError(t, err)
+	raw, err := base64.StdEncoding.DecodeString(key)
+	require.NoError(t, err)
+
+	shortKey := base64.StdEncoding.EncodeToString([]byte("too short"))
+
+	tests := []struct {
+		name          string
+		env           map[string]string
+		source        KeySource
+		want          []byte
+		errorContains string
+	}{
+		{
+			name:   "Key file with trailing newline",
+			source: KeyFile(writeFile("key", key+"\n")),
+			want:   raw,
+		},
+		{
+			name:   "Key environment variable",
+			env:    map[string]string{"KAUDIT_KEY": key},
+			source: KeyEnv("KAUDIT_KEY"),
+			want:   raw,
+		},
+		{
+			name:   "Passphrase file keeps inner whitespace",
+			source: PassphraseFile(writeFile("passphrase", " secret phrase \n")),
+			want:   []byte(" secret phrase "),
+		},
+		{
+			name:   "Passphrase environment variable",
+			env:    map[string]string{"KAUDIT_PASSPHRASE": "secret"},
+			source: PassphraseEnv("KAUDIT_PASSPHRASE"),
+			want:   []byte("secret"),
+		},
+		{
+			name:          "Key of wrong size",
+			env:           map[string]string{"KAUDIT_KEY": shortKey},
+			source:        KeyEnv("KAUDIT_KEY"),
+			errorContains: "holds a 9 byte key, want 32 bytes",
+		},
+		{
+			name:          "Key not base64",
+			env:           map[string]string{"KAUDIT_KEY": "not base64!"},
+			source:        KeyEnv("KAUDIT_KEY"),
+			errorContains: "base64 decoding of key environment variable KAUDIT_KEY failed",
+		},
+		{
+			name:          "Unset environment variable",
+			env:           map[string]string{"KAUDIT_PASSPHRASE": ""},
+			source:        PassphraseEnv("KAUDIT_PASSPHRASE"),
+			errorContains: `environment variable "KAUDIT_PASSPHRASE" is not set`,
+		},
+		{
+			name:          "Empty passphrase file",
+			source:        PassphraseFile(writeFile("empty", "\n")),
+			errorContains: "is empty",
+		},
+		{
+			name:          "Missing key file",
+			source:        KeyFile(filepath.Join(dir, "missing")),
+			errorContains: "val.ValidateWithTag failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			secret, err := tt.source.secret()
+
+			if tt.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, secret)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, secret)
+		})
+	}
+}
+
+func TestGenerateKey(t *testing.T) {
+	first, err := GenerateKey()
+	require.NoError(t, err)
+	second, err := GenerateKey()
+	require.NoError(t, err)
+
+	assert.NotEqual(t, first, second)
+
+	raw, err := base64.StdEncoding.DecodeString(first)
+	require.NoError(t, err)
+	assert.Len(t, raw, KeySize)
+}
@@ -0,0 +1,70 @@
+package kubeconfig
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	api "github.com/kaudit/k8s_client"
+)
+
+// MergedConfigLoader implements the auth.K8sAuthLoader interface following kubectl semantics.
+// It merges the files listed in the KUBECONFIG environment variable and falls back to
+// ~/.kube/config when the variable is unset.
+type MergedConfigLoader struct {
+	home string
+}
+
+// NewMergedConfigLoader returns a new instance of MergedConfigLoader.
+// The KUBECONFIG environment variable is read on every Load, not when the loader is created.
+func NewMergedConfigLoader() api.K8sAuthLoader {
+	return &MergedConfigLoader{home: clientcmd.RecommendedHomeFile}
+}
+
+// Load merges the kubeconfig files of the search path and returns the result serialized
+// as a single kubeconfig.
+//
+// Files are merged in order: the first file setting a value, such as current-context or
+// a cluster of a given name, wins. Missing files are skipped, as kubectl does, and relative
+// paths in a file are resolved against its directory so the result is usable on its own.
+//
+// It returns an error if a file cannot be parsed or none of the files exists.
+func (m *MergedConfigLoader) Load() ([]byte, error) {
+	paths := m.searchPath()
+
+	rules := &clientcmd.ClientConfigLoadingRules{Precedence: paths}
+	config, err := rules.Load()
+	if err != nil {
+		return nil, fmt.Errorf("rules.Load failed: %w", err)
+	}
+
+	if clientcmdapi.IsConfigEmpty(config) {
+		return nil, fmt.Errorf("no kubeconfig found in %v", paths)
+	}
+
+	b, err := clientcmd.Write(*config)
+	if err != nil {
+		return nil, fmt.Errorf("clientcmd.Write failed: %w", err)
+	}
+
+	return b, nil
+}
+
+// searchPath returns the kubeconfig files to merge in order of precedence.
+func (m *MergedConfigLoader) searchPath() []string {
+	var paths []string
+	for _, path := range filepath.SplitList(os.Getenv(clientcmd.RecommendedConfigPathEnvVar)) {
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+
+	if len(paths) == 0 {
+		return []string{m.home}
+	}
+
+	return paths
+}
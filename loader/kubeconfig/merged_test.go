@@ -0,0 +1,120 @@
+package kubeconfig
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// writeKubeconfig writes a kubeconfig with a single context named name to dir and returns its path.
+func writeKubeconfig(t *testing.T, dir, name, server string) string {
+	t.Helper()
+
+	data := `
+apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: ` + server + `
+    certificate-authority: ca.crt
+  name: ` + name + `
+contexts:
+- context:
+    cluster: ` + name + `
+    user: ` + name + `
+  name: ` + name + `
+current-context: ` + name + `
+users:
+- name: ` + name + `
+  user:
+    token: ` + name + `-token
+`
+
+	path := filepath.Join(dir, name+".yaml")
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+
+	return path
+}
+
+func TestMergedConfigLoader_Load(t *testing.T) {
+	dir := t.TempDir()
+	first := writeKubeconfig(t, dir, "first", "https://first.example.com")
+	second := writeKubeconfig(t, dir, "second", "https://second.example.com")
+	home := writeKubeconfig(t, dir, "home", "https://home.example.com")
+	invalid := filepath.Join(dir, "invalid.yaml")
+	require.NoError(t, os.WriteFile(invalid, []byte("invalid: [yaml"), 0o600))
+
+	tests := []struct {
+		name          string
+		kubeconfig    string
+		wantContexts  []string
+		wantCurrent   string
+		wantCA        string
+		errorContains string
+	}{
+		{
+			name:         "Merges KUBECONFIG files in order",
+			kubeconfig:   strings.Join([]string{first, nonExistPath, second}, string(os.PathListSeparator)),
+			wantContexts: []string{"first", "second"},
+			wantCurrent:  "first",
+			wantCA:       filepath.Join(dir, "ca.crt"),
+		},
+		{
+			name:         "Falls back to home kubeconfig",
+			wantContexts: []string{"home"},
+			wantCurrent:  "home",
+			wantCA:       filepath.Join(dir, "ca.crt"),
+		},
+		{
+			name:          "Fails when no file exists",
+			kubeconfig:    nonExistPath,
+			errorContains: "no kubeconfig found",
+		},
+		{
+			name:          "Fails on invalid file",
+			kubeconfig:    strings.Join([]string{first, invalid}, string(os.PathListSeparator)),
+			errorContains: "rules.Load failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(clientcmd.RecommendedConfigPathEnvVar, tt.kubeconfig)
+			l := &MergedConfigLoader{home: home}
+
+			data, err := l.Load()
+
+			if tt.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, data)
+				return
+			}
+
+			require.NoError(t, err)
+
+			config, err := clientcmd.Load(data)
+			require.NoError(t, err)
+
+			var contexts []string
+			for name := range config.Contexts {
+				contexts = append(contexts, name)
+			}
+			assert.ElementsMatch(t, tt.wantContexts, contexts)
+			assert.Equal(t, tt.wantCurrent, config.CurrentContext)
+			assert.Equal(t, tt.wantCA, config.Clusters[tt.wantCurrent].CertificateAuthority)
+		})
+	}
+}
+
+func TestNewMergedConfigLoader(t *testing.T) {
+	l := NewMergedConfigLoader()
+
+	require.IsType(t, &MergedConfigLoader{}, l)
+	assert.Equal(t, clientcmd.RecommendedHomeFile, l.(*MergedConfigLoader).home)
+}
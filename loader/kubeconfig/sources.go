@@ -0,0 +1,167 @@
+package kubeconfig
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/kaudit/val"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	api "github.com/kaudit/k8s_client"
+)
+
+// ErrEmptyKubeconfig is returned when a payload parses but defines no clusters, users or contexts.
+var ErrEmptyKubeconfig = errors.New("kubeconfig is empty")
+
+// BytesLoader implements the auth.K8sAuthLoader interface.
+// It returns kubeconfig data held in memory.
+type BytesLoader struct {
+	data []byte
+}
+
+// NewBytesLoader returns a new instance of BytesLoader serving a copy of data.
+func NewBytesLoader(data []byte) api.K8sAuthLoader {
+	return &BytesLoader{data: append([]byte(nil), data...)}
+}
+
+// Load returns the kubeconfig data of the loader.
+// It returns an error if the data does not parse as a kubeconfig.
+func (b *BytesLoader) Load() ([]byte, error) {
+	if err := validate(b.data); err != nil {
+		return nil, err
+	}
+
+	return append([]byte(nil), b.data...), nil
+}
+
+// EnvLoader implements the auth.K8sAuthLoader interface.
+// It loads kubeconfig data from an environment variable, as CI pipelines inject secrets.
+type EnvLoader struct {
+	name   string
+	base64 bool
+}
+
+// NewEnvLoader returns a new instance of EnvLoader reading the raw kubeconfig from the
+// environment variable name.
+func NewEnvLoader(name string) api.K8sAuthLoader {
+	return &EnvLoader{name: name}
+}
+
+// NewBase64EnvLoader returns a new instance of EnvLoader reading a base64 encoded kubeconfig
+// from the environment variable name. Line breaks in the encoded value are ignored.
+func NewBase64EnvLoader(name string) api.K8sAuthLoader {
+	return &EnvLoader{name: name, base64: true}
+}
+
+// Load reads the environment variable of the loader, decoding it if needed.
+// The variable is read on every call.
+// It returns an error if the variable is unset or empty, cannot be decoded, or does not
+// parse as a kubeconfig.
+func (e *EnvLoader) Load() ([]byte, error) {
+	err := val.ValidateWithTag(e.name, "required")
+	if err != nil {
+		return nil, fmt.Errorf("val.ValidateWithTag failed: %w", err)
+	}
+
+	value := os.Getenv(e.name)
+	if value == "" {
+		return nil, fmt.Errorf("environment variable %q is not set", e.name)
+	}
+
+	data := []byte(value)
+	if e.base64 {
+		data, err = base64.StdEncoding.DecodeString(strings.Join(strings.Fields(value), ""))
+		if err != nil {
+			return nil, fmt.Errorf("base64 decoding of environment variable %q failed: %w", e.name, err)
+		}
+	}
+
+	if err = validate(data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// ReaderLoader implements the auth.K8sAuthLoader interface.
+// It loads kubeconfig data from an io.Reader such as a pipe.
+//
+// The reader is consumed by the first Load; later calls return the same data, so the loader
+// can be shared by everything that reloads the kubeconfig.
+type ReaderLoader struct {
+	reader io.Reader
+
+	mu   sync.Mutex
+	read bool
+	data []byte
+	err  error
+}
+
+// NewReaderLoader returns a new instance of ReaderLoader reading from r.
+func NewReaderLoader(r io.Reader) api.K8sAuthLoader {
+	return &ReaderLoader{reader: r}
+}
+
+// NewStdinLoader returns a new instance of ReaderLoader reading from standard input,
+// e.g. for `vault read ... | kaudit`.
+func NewStdinLoader() api.K8sAuthLoader {
+	return NewReaderLoader(os.Stdin)
+}
+
+// Load reads the reader to the end on the first call and returns a copy of the data.
+// It returns an error if reading fails or the data does not parse as a kubeconfig;
+// the error is returned by every later call as well.
+func (r *ReaderLoader) Load() ([]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.read {
+		r.read = true
+		r.data, r.err = r.readAll()
+	}
+
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	return append([]byte(nil), r.data...), nil
+}
+
+// readAll reads and validates the kubeconfig of the reader.
+func (r *ReaderLoader) readAll() ([]byte, error) {
+	if r.reader == nil {
+		return nil, errors.New("reader must not be nil")
+	}
+
+	data, err := io.ReadAll(r.reader)
+	if err != nil {
+		return nil, fmt.Errorf("io.ReadAll failed: %w", err)
+	}
+
+	if err = validate(data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// validate checks that data parses as a kubeconfig that defines at least a cluster,
+// a user or a context.
+func validate(data []byte) error {
+	config, err := clientcmd.Load(data)
+	if err != nil {
+		return fmt.Errorf("clientcmd.Load failed: %w", err)
+	}
+
+	if clientcmdapi.IsConfigEmpty(config) {
+		return ErrEmptyKubeconfig
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package kubeconfig
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/kaudit/k8s_client"
+)
+
+const sampleKubeconfig = `
+apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://cluster.example.com
+  name: cluster
+contexts:
+- context:
+    cluster: cluster
+    user: auditor
+  name: cluster
+current-context: cluster
+users:
+- name: auditor
+  user:
+    token: auditor-token
+`
+
+func TestSourceLoaders(t *testing.T) {
+	encoded := base64.StdEncoding.EncodeToString([]byte(sampleKubeconfig))
+	wrapped := encoded[:40] + "\n" + encoded[40:] + "\n"
+
+	tests := []struct {
+		name          string
+		env           map[string]string
+		loader        api.K8sAuthLoader
+		want          string
+		errorIs       error
+		errorContains string
+	}{
+		{
+			name:   "Bytes",
+			loader: NewBytesLoader([]byte(sampleKubeconfig)),
+			want:   sampleKubeconfig,
+		},
+		{
+			name:          "Bytes that are not a kubeconfig",
+			loader:        NewBytesLoader([]byte("not a kubeconfig")),
+			errorContains: "clientcmd.Load failed",
+		},
+		{
+			name:    "Empty bytes",
+			loader:  NewBytesLoader(nil),
+			errorIs: ErrEmptyKubeconfig,
+		},
+		{
+			name:   "Raw environment variable",
+			env:    map[string]string{"KAUDIT_KUBECONFIG": sampleKubeconfig},
+			loader: NewEnvLoader("KAUDIT_KUBECONFIG"),
+			want:   sampleKubeconfig,
+		},
+		{
+			name:   "Base64 environment variable with line breaks",
+			env:    map[string]string{"KAUDIT_KUBECONFIG": wrapped},
+			loader: NewBase64EnvLoader("KAUDIT_KUBECONFIG"),
+			want:   sampleKubeconfig,
+		},
+		{
+			name:          "Invalid base64 environment variable",
+			env:           map[string]string{"KAUDIT_KUBECONFIG": "not base64!"},
+			loader:        NewBase64EnvLoader("KAUDIT_KUBECONFIG"),
+			errorContains: "base64 decoding of environment variable \"KAUDIT_KUBECONFIG\" failed",
+		},
+		{
+			name:          "Unset environment variable",
+			env:           map[string]string{"KAUDIT_KUBECONFIG": ""},
+			loader:        NewEnvLoader("KAUDIT_KUBECONFIG"),
+			errorContains: "environment variable \"KAUDIT_KUBECONFIG\" is not set",
+		},
+		{
+			name:          "Empty environment variable name",
+			loader:        NewEnvLoader(""),
+			errorContains: "val.ValidateWithTag failed",
+		},
+		{
+			name:   "Reader",
+			loader: NewReaderLoader(strings.NewReader(sampleKubeconfig)),
+			want:   sampleKubeconfig,
+		},
+		{
+			name:          "Failing reader",
+			loader:        NewReaderLoader(iotest.ErrReader(errors.New("broken pipe"))),
+			errorContains: "io.ReadAll failed: broken pipe",
+		},
+		{
+			name:          "Nil reader",
+			loader:        NewReaderLoader(nil),
+			errorContains: "reader must not be nil",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			data, err := tt.loader.Load()
+
+			if tt.errorIs != nil || tt.errorContains != "" {
+				require.Error(t, err)
+				if tt.errorIs != nil {
+					assert.ErrorIs(t, err, tt.errorIs)
+				}
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, data)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(data))
+		})
+	}
+}
+
+func TestReaderLoader_LoadTwice(t *testing.T) {
+	l := NewReaderLoader(strings.NewReader(sampleKubeconfig))
+
+	first, err := l.Load()
+	require.NoError(t, err)
+
+	first[0] = 'X'
+
+	second, err := l.Load()
+	require.NoError(t, err)
+	assert.Equal(t, sampleKubeconfig, string(second))
+}
+
+func TestNewStdinLoader(t *testing.T) {
+	assert.IsType(t, &ReaderLoader{}, NewStdinLoader())
+}
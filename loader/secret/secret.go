@@ -0,0 +1,123 @@
+// Package secret loads kubeconfigs stored in Kubernetes Secrets, so a hub cluster can hold
+// the credentials of the spoke clusters it audits.
+package secret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kaudit/val"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	api "github.com/kaudit/k8s_client"
+	"github.com/kaudit/k8s_client/loader/kubeconfig"
+)
+
+// DefaultKey is the Secret key holding the kubeconfig when no other key is given,
+// as used by Cluster API and most GitOps tools.
+const DefaultKey = "value"
+
+// DefaultTimeout bounds the request reading the Secret.
+const DefaultTimeout = 30 * time.Second
+
+// SecretLoader implements the auth.K8sAuthLoader interface.
+// It reads a kubeconfig from a key of a Secret through an existing connection.
+//
+// The Secret is read on every Load, so rotated credentials are picked up. File references
+// in the stored kubeconfig, such as certificate-authority paths, are resolved on the local
+// filesystem; embedded data fields should be used instead.
+type SecretLoader struct {
+	clientset kubernetes.Interface
+	namespace string
+	name      string
+	key       string
+	timeout   time.Duration
+}
+
+// Option configures a SecretLoader.
+type Option func(*SecretLoader)
+
+// WithKey sets the Secret key holding the kubeconfig. It defaults to DefaultKey.
+func WithKey(key string) Option {
+	return func(s *SecretLoader) {
+		s.key = key
+	}
+}
+
+// WithTimeout sets the timeout of the request reading the Secret. It defaults to DefaultTimeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(s *SecretLoader) {
+		s.timeout = timeout
+	}
+}
+
+// NewSecretLoader returns a new instance of SecretLoader reading the Secret name in namespace.
+// The clientset is typically the one of the hub cluster, see k8sclient.K8sClient.GetClientset.
+func NewSecretLoader(clientset kubernetes.Interface, namespace, name string, opts ...Option) api.K8sAuthLoader {
+	s := &SecretLoader{
+		clientset: clientset,
+		namespace: namespace,
+		name:      name,
+		key:       DefaultKey,
+		timeout:   DefaultTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Load reads the Secret and returns the kubeconfig stored under the key of the loader.
+// It returns an error if the input is invalid, the Secret cannot be read, the key is missing,
+// or its value does not parse as a kubeconfig.
+func (s *SecretLoader) Load() ([]byte, error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	secret, err := s.clientset.CoreV1().Secrets(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret %s/%s: %w", s.namespace, s.name, err)
+	}
+
+	data, ok := secret.Data[s.key]
+	if !ok {
+		return nil, fmt.Errorf("secret %s/%s has no key %q", s.namespace, s.name, s.key)
+	}
+
+	b, err := kubeconfig.NewBytesLoader(data).Load()
+	if err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig in secret %s/%s key %q: %w", s.namespace, s.name, s.key, err)
+	}
+
+	return b, nil
+}
+
+// validate checks the settings of the loader.
+func (s *SecretLoader) validate() error {
+	if s.clientset == nil {
+		return errors.New("invalid clientset: must not be nil")
+	}
+	if err := val.ValidateWithTag(s.namespace, "required"); err != nil {
+		return fmt.Errorf("invalid namespace: %w", err)
+	}
+	if err := val.ValidateWithTag(s.name, "required"); err != nil {
+		return fmt.Errorf("invalid secret name: %w", err)
+	}
+	if err := val.ValidateWithTag(s.key, "required"); err != nil {
+		return fmt.Errorf("invalid secret key: %w", err)
+	}
+	if err := val.ValidateWithTag(s.timeout, "gt=0"); err != nil {
+		return fmt.Errorf("invalid timeout: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package secret
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const spokeKubeconfig = `
+apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://spoke.example.com
+  name: spoke
+contexts:
+- context:
+    cluster: spoke
+    user: auditor
+  name: spoke
+current-context: spoke
+users:
+- name: auditor
+  user:
+    token: spoke-token
+`
+
+func TestSecretLoader_Load(t *testing.T) {
+	clientset := fake.NewClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "spoke-kubeconfig", Namespace: "fleet"},
+		Data: map[string][]byte{
+			DefaultKey: []byte(spokeKubeconfig),
+			"admin":    []byte(spokeKubeconfig),
+			"broken":   []byte("not a kubeconfig"),
+		},
+	})
+
+	tests := []struct {
+		name          string
+		clientset     kubernetes.Interface
+		namespace     string
+		secret        string
+		opts          []Option
+		wantNotFound  bool
+		errorContains string
+	}{
+		{
+			name:      "Default key",
+			clientset: clientset,
+			namespace: "fleet",
+			secret:    "spoke-kubeconfig",
+		},
+		{
+			name:      "Custom key",
+			clientset: clientset,
+			namespace: "fleet",
+			secret:    "spoke-kubeconfig",
+			opts:      []Option{WithKey("admin"), WithTimeout(time.Second)},
+		},
+		{
+			name:          "Missing key",
+			clientset:     clientset,
+			namespace:     "fleet",
+			secret:        "spoke-kubeconfig",
+			opts:          []Option{WithKey("missing")},
+			errorContains: `secret fleet/spoke-kubeconfig has no key "missing"`,
+		},
+		{
+			name:          "Invalid kubeconfig",
+			clientset:     clientset,
+			namespace:     "fleet",
+			secret:        "spoke-kubeconfig",
+			opts:          []Option{WithKey("broken")},
+			errorContains: `invalid kubeconfig in secret fleet/spoke-kubeconfig key "broken"`,
+		},
+		{
+			name:          "Missing secret",
+			clientset:     clientset,
+			namespace:     "fleet",
+			secret:        "missing",
+			wantNotFound:  true,
+			errorContains: "failed to get secret fleet/missing",
+		},
+		{
+			name:          "Nil clientset",
+			namespace:     "fleet",
+			secret:        "spoke-kubeconfig",
+			errorContains: "invalid clientset",
+		},
+		{
+			name:          "Empty namespace",
+			clientset:     clientset,
+			secret:        "spoke-kubeconfig",
+			errorContains: "invalid namespace",
+		},
+		{
+			name:          "Empty secret name",
+			clientset:     clientset,
+			namespace:     "fleet",
+			errorContains: "invalid secret name",
+		},
+		{
+			name:          "Empty key",
+			clientset:     clientset,
+			namespace:     "fleet",
+			secret:        "spoke-kubeconfig",
+			opts:          []Option{WithKey("")},
+			errorContains: "invalid secret key",
+		},
+		{
+			name:          "Invalid timeout",
+			clientset:     clientset,
+			namespace:     "fleet",
+			secret:        "spoke-kubeconfig",
+			opts:          []Option{WithTimeout(0)},
+			errorContains: "invalid timeout",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewSecretLoader(tt.clientset, tt.namespace, tt.secret, tt.opts...)
+
+			data, err := l.Load()
+
+			if tt.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Equal(t, tt.wantNotFound, apierrors.IsNotFound(err))
+				assert.Nil(t, data)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, spokeKubeconfig, string(data))
+		})
+	}
+}
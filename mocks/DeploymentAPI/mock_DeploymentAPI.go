@@ -1,11 +1,18 @@
-// Code generated by mockery v2.51.1. DO NOT EDIT.
+// Code generated by mockery v2.53.4. DO NOT EDIT.
 
 package mocksapi
 
 import (
 	context "context"
 
+	api "github.com/kaudit/k8s_client"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
 	v1 "k8s.io/api/apps/v1"
 )
 
@@ -82,9 +89,9 @@ func (_c *MockDeploymentAPI_GetDeploymentByName_Call) RunAndReturn(run func(cont
 	return _c
 }
 
-// ListDeploymentsByField provides a mock function with given fields: ctx, namespace, fieldSelector
-func (_m *MockDeploymentAPI) ListDeploymentsByField(ctx context.Context, namespace string, fieldSelector string) ([]v1.Deployment, error) {
-	ret := _m.Called(ctx, namespace, fieldSelector)
+// ListDeploymentsByField provides a mock function with given fields: ctx, namespace, fieldSelector, timeoutSeconds, limit
+func (_m *MockDeploymentAPI) ListDeploymentsByField(ctx context.Context, namespace string, fieldSelector string, timeoutSeconds time.Duration, limit int64) ([]v1.Deployment, error) {
+	ret := _m.Called(ctx, namespace, fieldSelector, timeoutSeconds, limit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListDeploymentsByField")
@@ -92,19 +99,19 @@ func (_m *MockDeploymentAPI) ListDeploymentsByField(ctx context.Context, namespa
 
 	var r0 []v1.Deployment
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]v1.Deployment, error)); ok {
-		return rf(ctx, namespace, fieldSelector)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration, int64) ([]v1.Deployment, error)); ok {
+		return rf(ctx, namespace, fieldSelector, timeoutSeconds, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) []v1.Deployment); ok {
-		r0 = rf(ctx, namespace, fieldSelector)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration, int64) []v1.Deployment); ok {
+		r0 = rf(ctx, namespace, fieldSelector, timeoutSeconds, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]v1.Deployment)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, namespace, fieldSelector)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Duration, int64) error); ok {
+		r1 = rf(ctx, namespace, fieldSelector, timeoutSeconds, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -121,13 +128,15 @@ type MockDeploymentAPI_ListDeploymentsByField_Call struct {
 //   - ctx context.Context
 //   - namespace string
 //   - fieldSelector string
-func (_e *MockDeploymentAPI_Expecter) ListDeploymentsByField(ctx interface{}, namespace interface{}, fieldSelector interface{}) *MockDeploymentAPI_ListDeploymentsByField_Call {
-	return &MockDeploymentAPI_ListDeploymentsByField_Call{Call: _e.mock.On("ListDeploymentsByField", ctx, namespace, fieldSelector)}
+//   - timeoutSeconds time.Duration
+//   - limit int64
+func (_e *MockDeploymentAPI_Expecter) ListDeploymentsByField(ctx interface{}, namespace interface{}, fieldSelector interface{}, timeoutSeconds interface{}, limit interface{}) *MockDeploymentAPI_ListDeploymentsByField_Call {
+	return &MockDeploymentAPI_ListDeploymentsByField_Call{Call: _e.mock.On("ListDeploymentsByField", ctx, namespace, fieldSelector, timeoutSeconds, limit)}
 }
 
-func (_c *MockDeploymentAPI_ListDeploymentsByField_Call) Run(run func(ctx context.Context, namespace string, fieldSelector string)) *MockDeploymentAPI_ListDeploymentsByField_Call {
+func (_c *MockDeploymentAPI_ListDeploymentsByField_Call) Run(run func(ctx context.Context, namespace string, fieldSelector string, timeoutSeconds time.Duration, limit int64)) *MockDeploymentAPI_ListDeploymentsByField_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Duration), args[4].(int64))
 	})
 	return _c
 }
@@ -137,14 +146,14 @@ func (_c *MockDeploymentAPI_ListDeploymentsByField_Call) Return(_a0 []v1.Deploym
 	return _c
 }
 
-func (_c *MockDeploymentAPI_ListDeploymentsByField_Call) RunAndReturn(run func(context.Context, string, string) ([]v1.Deployment, error)) *MockDeploymentAPI_ListDeploymentsByField_Call {
+func (_c *MockDeploymentAPI_ListDeploymentsByField_Call) RunAndReturn(run func(context.Context, string, string, time.Duration, int64) ([]v1.Deployment, error)) *MockDeploymentAPI_ListDeploymentsByField_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListDeploymentsByLabel provides a mock function with given fields: ctx, namespace, labelSelector
-func (_m *MockDeploymentAPI) ListDeploymentsByLabel(ctx context.Context, namespace string, labelSelector string) ([]v1.Deployment, error) {
-	ret := _m.Called(ctx, namespace, labelSelector)
+// ListDeploymentsByLabel provides a mock function with given fields: ctx, namespace, labelSelector, timeoutSeconds, limit
+func (_m *MockDeploymentAPI) ListDeploymentsByLabel(ctx context.Context, namespace string, labelSelector string, timeoutSeconds time.Duration, limit int64) ([]v1.Deployment, error) {
+	ret := _m.Called(ctx, namespace, labelSelector, timeoutSeconds, limit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListDeploymentsByLabel")
@@ -152,19 +161,19 @@ func (_m *MockDeploymentAPI) ListDeploymentsByLabel(ctx context.Context, namespa
 
 	var r0 []v1.Deployment
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]v1.Deployment, error)); ok {
-		return rf(ctx, namespace, labelSelector)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration, int64) ([]v1.Deployment, error)); ok {
+		return rf(ctx, namespace, labelSelector, timeoutSeconds, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) []v1.Deployment); ok {
-		r0 = rf(ctx, namespace, labelSelector)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration, int64) []v1.Deployment); ok {
+		r0 = rf(ctx, namespace, labelSelector, timeoutSeconds, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]v1.Deployment)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, namespace, labelSelector)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Duration, int64) error); ok {
+		r1 = rf(ctx, namespace, labelSelector, timeoutSeconds, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -181,13 +190,15 @@ type MockDeploymentAPI_ListDeploymentsByLabel_Call struct {
 //   - ctx context.Context
 //   - namespace string
 //   - labelSelector string
-func (_e *MockDeploymentAPI_Expecter) ListDeploymentsByLabel(ctx interface{}, namespace interface{}, labelSelector interface{}) *MockDeploymentAPI_ListDeploymentsByLabel_Call {
-	return &MockDeploymentAPI_ListDeploymentsByLabel_Call{Call: _e.mock.On("ListDeploymentsByLabel", ctx, namespace, labelSelector)}
+//   - timeoutSeconds time.Duration
+//   - limit int64
+func (_e *MockDeploymentAPI_Expecter) ListDeploymentsByLabel(ctx interface{}, namespace interface{}, labelSelector interface{}, timeoutSeconds interface{}, limit interface{}) *MockDeploymentAPI_ListDeploymentsByLabel_Call {
+	return &MockDeploymentAPI_ListDeploymentsByLabel_Call{Call: _e.mock.On("ListDeploymentsByLabel", ctx, namespace, labelSelector, timeoutSeconds, limit)}
 }
 
-func (_c *MockDeploymentAPI_ListDeploymentsByLabel_Call) Run(run func(ctx context.Context, namespace string, labelSelector string)) *MockDeploymentAPI_ListDeploymentsByLabel_Call {
+func (_c *MockDeploymentAPI_ListDeploymentsByLabel_Call) Run(run func(ctx context.Context, namespace string, labelSelector string, timeoutSeconds time.Duration, limit int64)) *MockDeploymentAPI_ListDeploymentsByLabel_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Duration), args[4].(int64))
 	})
 	return _c
 }
@@ -197,7 +208,249 @@ func (_c *MockDeploymentAPI_ListDeploymentsByLabel_Call) Return(_a0 []v1.Deploym
 	return _c
 }
 
-func (_c *MockDeploymentAPI_ListDeploymentsByLabel_Call) RunAndReturn(run func(context.Context, string, string) ([]v1.Deployment, error)) *MockDeploymentAPI_ListDeploymentsByLabel_Call {
+func (_c *MockDeploymentAPI_ListDeploymentsByLabel_Call) RunAndReturn(run func(context.Context, string, string, time.Duration, int64) ([]v1.Deployment, error)) *MockDeploymentAPI_ListDeploymentsByLabel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDeploymentsByQuery provides a mock function with given fields: ctx, namespace, query
+func (_m *MockDeploymentAPI) ListDeploymentsByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]v1.Deployment, error) {
+	ret := _m.Called(ctx, namespace, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeploymentsByQuery")
+	}
+
+	var r0 []v1.Deployment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, api.ListQuery) ([]v1.Deployment, error)); ok {
+		return rf(ctx, namespace, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, api.ListQuery) []v1.Deployment); ok {
+		r0 = rf(ctx, namespace, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]v1.Deployment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, api.ListQuery) error); ok {
+		r1 = rf(ctx, namespace, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDeploymentAPI_ListDeploymentsByQuery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDeploymentsByQuery'
+type MockDeploymentAPI_ListDeploymentsByQuery_Call struct {
+	*mock.Call
+}
+
+// ListDeploymentsByQuery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+//   - query api.ListQuery
+func (_e *MockDeploymentAPI_Expecter) ListDeploymentsByQuery(ctx interface{}, namespace interface{}, query interface{}) *MockDeploymentAPI_ListDeploymentsByQuery_Call {
+	return &MockDeploymentAPI_ListDeploymentsByQuery_Call{Call: _e.mock.On("ListDeploymentsByQuery", ctx, namespace, query)}
+}
+
+func (_c *MockDeploymentAPI_ListDeploymentsByQuery_Call) Run(run func(ctx context.Context, namespace string, query api.ListQuery)) *MockDeploymentAPI_ListDeploymentsByQuery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(api.ListQuery))
+	})
+	return _c
+}
+
+func (_c *MockDeploymentAPI_ListDeploymentsByQuery_Call) Return(_a0 []v1.Deployment, _a1 error) *MockDeploymentAPI_ListDeploymentsByQuery_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDeploymentAPI_ListDeploymentsByQuery_Call) RunAndReturn(run func(context.Context, string, api.ListQuery) ([]v1.Deployment, error)) *MockDeploymentAPI_ListDeploymentsByQuery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDeploymentsMetadata provides a mock function with given fields: ctx, namespace, labelSelector, timeoutSeconds, limit
+func (_m *MockDeploymentAPI) ListDeploymentsMetadata(ctx context.Context, namespace string, labelSelector string, timeoutSeconds time.Duration, limit int64) ([]metav1.PartialObjectMetadata, error) {
+	ret := _m.Called(ctx, namespace, labelSelector, timeoutSeconds, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeploymentsMetadata")
+	}
+
+	var r0 []metav1.PartialObjectMetadata
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration, int64) ([]metav1.PartialObjectMetadata, error)); ok {
+		return rf(ctx, namespace, labelSelector, timeoutSeconds, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration, int64) []metav1.PartialObjectMetadata); ok {
+		r0 = rf(ctx, namespace, labelSelector, timeoutSeconds, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]metav1.PartialObjectMetadata)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Duration, int64) error); ok {
+		r1 = rf(ctx, namespace, labelSelector, timeoutSeconds, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDeploymentAPI_ListDeploymentsMetadata_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDeploymentsMetadata'
+type MockDeploymentAPI_ListDeploymentsMetadata_Call struct {
+	*mock.Call
+}
+
+// ListDeploymentsMetadata is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+//   - labelSelector string
+//   - timeoutSeconds time.Duration
+//   - limit int64
+func (_e *MockDeploymentAPI_Expecter) ListDeploymentsMetadata(ctx interface{}, namespace interface{}, labelSelector interface{}, timeoutSeconds interface{}, limit interface{}) *MockDeploymentAPI_ListDeploymentsMetadata_Call {
+	return &MockDeploymentAPI_ListDeploymentsMetadata_Call{Call: _e.mock.On("ListDeploymentsMetadata", ctx, namespace, labelSelector, timeoutSeconds, limit)}
+}
+
+func (_c *MockDeploymentAPI_ListDeploymentsMetadata_Call) Run(run func(ctx context.Context, namespace string, labelSelector string, timeoutSeconds time.Duration, limit int64)) *MockDeploymentAPI_ListDeploymentsMetadata_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Duration), args[4].(int64))
+	})
+	return _c
+}
+
+func (_c *MockDeploymentAPI_ListDeploymentsMetadata_Call) Return(_a0 []metav1.PartialObjectMetadata, _a1 error) *MockDeploymentAPI_ListDeploymentsMetadata_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDeploymentAPI_ListDeploymentsMetadata_Call) RunAndReturn(run func(context.Context, string, string, time.Duration, int64) ([]metav1.PartialObjectMetadata, error)) *MockDeploymentAPI_ListDeploymentsMetadata_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDeploymentsSnapshot provides a mock function with given fields: ctx, namespace, opts
+func (_m *MockDeploymentAPI) ListDeploymentsSnapshot(ctx context.Context, namespace string, opts api.SnapshotOptions) (*api.Snapshot[v1.Deployment], error) {
+	ret := _m.Called(ctx, namespace, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeploymentsSnapshot")
+	}
+
+	var r0 *api.Snapshot[v1.Deployment]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, api.SnapshotOptions) (*api.Snapshot[v1.Deployment], error)); ok {
+		return rf(ctx, namespace, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, api.SnapshotOptions) *api.Snapshot[v1.Deployment]); ok {
+		r0 = rf(ctx, namespace, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.Snapshot[v1.Deployment])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, api.SnapshotOptions) error); ok {
+		r1 = rf(ctx, namespace, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDeploymentAPI_ListDeploymentsSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDeploymentsSnapshot'
+type MockDeploymentAPI_ListDeploymentsSnapshot_Call struct {
+	*mock.Call
+}
+
+// ListDeploymentsSnapshot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+//   - opts api.SnapshotOptions
+func (_e *MockDeploymentAPI_Expecter) ListDeploymentsSnapshot(ctx interface{}, namespace interface{}, opts interface{}) *MockDeploymentAPI_ListDeploymentsSnapshot_Call {
+	return &MockDeploymentAPI_ListDeploymentsSnapshot_Call{Call: _e.mock.On("ListDeploymentsSnapshot", ctx, namespace, opts)}
+}
+
+func (_c *MockDeploymentAPI_ListDeploymentsSnapshot_Call) Run(run func(ctx context.Context, namespace string, opts api.SnapshotOptions)) *MockDeploymentAPI_ListDeploymentsSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(api.SnapshotOptions))
+	})
+	return _c
+}
+
+func (_c *MockDeploymentAPI_ListDeploymentsSnapshot_Call) Return(_a0 *api.Snapshot[v1.Deployment], _a1 error) *MockDeploymentAPI_ListDeploymentsSnapshot_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDeploymentAPI_ListDeploymentsSnapshot_Call) RunAndReturn(run func(context.Context, string, api.SnapshotOptions) (*api.Snapshot[v1.Deployment], error)) *MockDeploymentAPI_ListDeploymentsSnapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WatchDeployments provides a mock function with given fields: ctx, namespace, opts
+func (_m *MockDeploymentAPI) WatchDeployments(ctx context.Context, namespace string, opts api.WatchOptions) (<-chan api.WatchEvent[*v1.Deployment], error) {
+	ret := _m.Called(ctx, namespace, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WatchDeployments")
+	}
+
+	var r0 <-chan api.WatchEvent[*v1.Deployment]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, api.WatchOptions) (<-chan api.WatchEvent[*v1.Deployment], error)); ok {
+		return rf(ctx, namespace, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, api.WatchOptions) <-chan api.WatchEvent[*v1.Deployment]); ok {
+		r0 = rf(ctx, namespace, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan api.WatchEvent[*v1.Deployment])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, api.WatchOptions) error); ok {
+		r1 = rf(ctx, namespace, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDeploymentAPI_WatchDeployments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WatchDeployments'
+type MockDeploymentAPI_WatchDeployments_Call struct {
+	*mock.Call
+}
+
+// WatchDeployments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+//   - opts api.WatchOptions
+func (_e *MockDeploymentAPI_Expecter) WatchDeployments(ctx interface{}, namespace interface{}, opts interface{}) *MockDeploymentAPI_WatchDeployments_Call {
+	return &MockDeploymentAPI_WatchDeployments_Call{Call: _e.mock.On("WatchDeployments", ctx, namespace, opts)}
+}
+
+func (_c *MockDeploymentAPI_WatchDeployments_Call) Run(run func(ctx context.Context, namespace string, opts api.WatchOptions)) *MockDeploymentAPI_WatchDeployments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(api.WatchOptions))
+	})
+	return _c
+}
+
+func (_c *MockDeploymentAPI_WatchDeployments_Call) Return(_a0 <-chan api.WatchEvent[*v1.Deployment], _a1 error) *MockDeploymentAPI_WatchDeployments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDeploymentAPI_WatchDeployments_Call) RunAndReturn(run func(context.Context, string, api.WatchOptions) (<-chan api.WatchEvent[*v1.Deployment], error)) *MockDeploymentAPI_WatchDeployments_Call {
 	_c.Call.Return(run)
 	return _c
 }
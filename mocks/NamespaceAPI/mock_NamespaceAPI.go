@@ -1,12 +1,19 @@
-// Code generated by mockery v2.51.1. DO NOT EDIT.
+// Code generated by mockery v2.53.4. DO NOT EDIT.
 
 package mocksapi
 
 import (
 	context "context"
 
+	api "github.com/kaudit/k8s_client"
+
+	corev1 "k8s.io/api/core/v1"
+
 	mock "github.com/stretchr/testify/mock"
-	v1 "k8s.io/api/core/v1"
+
+	time "time"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // MockNamespaceAPI is an autogenerated mock type for the NamespaceAPI type
@@ -23,24 +30,22 @@ func (_m *MockNamespaceAPI) EXPECT() *MockNamespaceAPI_Expecter {
 }
 
 // GetNamespaceByName provides a mock function with given fields: ctx, name
-func (_m *MockNamespaceAPI) GetNamespaceByName(ctx context.Context, name string) (*v1.Namespace, error) {
+func (_m *MockNamespaceAPI) GetNamespaceByName(ctx context.Context, name string) (string, error) {
 	ret := _m.Called(ctx, name)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetNamespaceByName")
 	}
 
-	var r0 *v1.Namespace
+	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*v1.Namespace, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
 		return rf(ctx, name)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *v1.Namespace); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
 		r0 = rf(ctx, name)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*v1.Namespace)
-		}
+		r0 = ret.Get(0).(string)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
@@ -71,39 +76,99 @@ func (_c *MockNamespaceAPI_GetNamespaceByName_Call) Run(run func(ctx context.Con
 	return _c
 }
 
-func (_c *MockNamespaceAPI_GetNamespaceByName_Call) Return(_a0 *v1.Namespace, _a1 error) *MockNamespaceAPI_GetNamespaceByName_Call {
+func (_c *MockNamespaceAPI_GetNamespaceByName_Call) Return(_a0 string, _a1 error) *MockNamespaceAPI_GetNamespaceByName_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockNamespaceAPI_GetNamespaceByName_Call) RunAndReturn(run func(context.Context, string) (*v1.Namespace, error)) *MockNamespaceAPI_GetNamespaceByName_Call {
+func (_c *MockNamespaceAPI_GetNamespaceByName_Call) RunAndReturn(run func(context.Context, string) (string, error)) *MockNamespaceAPI_GetNamespaceByName_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListNamespacesByField provides a mock function with given fields: ctx, fieldSelector
-func (_m *MockNamespaceAPI) ListNamespacesByField(ctx context.Context, fieldSelector string) ([]v1.Namespace, error) {
-	ret := _m.Called(ctx, fieldSelector)
+// ListNamespaces provides a mock function with given fields: ctx, timeoutSeconds, limit
+func (_m *MockNamespaceAPI) ListNamespaces(ctx context.Context, timeoutSeconds time.Duration, limit int64) ([]string, error) {
+	ret := _m.Called(ctx, timeoutSeconds, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListNamespaces")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration, int64) ([]string, error)); ok {
+		return rf(ctx, timeoutSeconds, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration, int64) []string); ok {
+		r0 = rf(ctx, timeoutSeconds, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Duration, int64) error); ok {
+		r1 = rf(ctx, timeoutSeconds, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockNamespaceAPI_ListNamespaces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListNamespaces'
+type MockNamespaceAPI_ListNamespaces_Call struct {
+	*mock.Call
+}
+
+// ListNamespaces is a helper method to define mock.On call
+//   - ctx context.Context
+//   - timeoutSeconds time.Duration
+//   - limit int64
+func (_e *MockNamespaceAPI_Expecter) ListNamespaces(ctx interface{}, timeoutSeconds interface{}, limit interface{}) *MockNamespaceAPI_ListNamespaces_Call {
+	return &MockNamespaceAPI_ListNamespaces_Call{Call: _e.mock.On("ListNamespaces", ctx, timeoutSeconds, limit)}
+}
+
+func (_c *MockNamespaceAPI_ListNamespaces_Call) Run(run func(ctx context.Context, timeoutSeconds time.Duration, limit int64)) *MockNamespaceAPI_ListNamespaces_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Duration), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockNamespaceAPI_ListNamespaces_Call) Return(_a0 []string, _a1 error) *MockNamespaceAPI_ListNamespaces_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockNamespaceAPI_ListNamespaces_Call) RunAndReturn(run func(context.Context, time.Duration, int64) ([]string, error)) *MockNamespaceAPI_ListNamespaces_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListNamespacesByField provides a mock function with given fields: ctx, fieldSelector, timeoutSeconds, limit
+func (_m *MockNamespaceAPI) ListNamespacesByField(ctx context.Context, fieldSelector string, timeoutSeconds time.Duration, limit int64) ([]string, error) {
+	ret := _m.Called(ctx, fieldSelector, timeoutSeconds, limit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListNamespacesByField")
 	}
 
-	var r0 []v1.Namespace
+	var r0 []string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) ([]v1.Namespace, error)); ok {
-		return rf(ctx, fieldSelector)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, int64) ([]string, error)); ok {
+		return rf(ctx, fieldSelector, timeoutSeconds, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) []v1.Namespace); ok {
-		r0 = rf(ctx, fieldSelector)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, int64) []string); ok {
+		r0 = rf(ctx, fieldSelector, timeoutSeconds, limit)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]v1.Namespace)
+			r0 = ret.Get(0).([]string)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, fieldSelector)
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration, int64) error); ok {
+		r1 = rf(ctx, fieldSelector, timeoutSeconds, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -119,50 +184,52 @@ type MockNamespaceAPI_ListNamespacesByField_Call struct {
 // ListNamespacesByField is a helper method to define mock.On call
 //   - ctx context.Context
 //   - fieldSelector string
-func (_e *MockNamespaceAPI_Expecter) ListNamespacesByField(ctx interface{}, fieldSelector interface{}) *MockNamespaceAPI_ListNamespacesByField_Call {
-	return &MockNamespaceAPI_ListNamespacesByField_Call{Call: _e.mock.On("ListNamespacesByField", ctx, fieldSelector)}
+//   - timeoutSeconds time.Duration
+//   - limit int64
+func (_e *MockNamespaceAPI_Expecter) ListNamespacesByField(ctx interface{}, fieldSelector interface{}, timeoutSeconds interface{}, limit interface{}) *MockNamespaceAPI_ListNamespacesByField_Call {
+	return &MockNamespaceAPI_ListNamespacesByField_Call{Call: _e.mock.On("ListNamespacesByField", ctx, fieldSelector, timeoutSeconds, limit)}
 }
 
-func (_c *MockNamespaceAPI_ListNamespacesByField_Call) Run(run func(ctx context.Context, fieldSelector string)) *MockNamespaceAPI_ListNamespacesByField_Call {
+func (_c *MockNamespaceAPI_ListNamespacesByField_Call) Run(run func(ctx context.Context, fieldSelector string, timeoutSeconds time.Duration, limit int64)) *MockNamespaceAPI_ListNamespacesByField_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration), args[3].(int64))
 	})
 	return _c
 }
 
-func (_c *MockNamespaceAPI_ListNamespacesByField_Call) Return(_a0 []v1.Namespace, _a1 error) *MockNamespaceAPI_ListNamespacesByField_Call {
+func (_c *MockNamespaceAPI_ListNamespacesByField_Call) Return(_a0 []string, _a1 error) *MockNamespaceAPI_ListNamespacesByField_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockNamespaceAPI_ListNamespacesByField_Call) RunAndReturn(run func(context.Context, string) ([]v1.Namespace, error)) *MockNamespaceAPI_ListNamespacesByField_Call {
+func (_c *MockNamespaceAPI_ListNamespacesByField_Call) RunAndReturn(run func(context.Context, string, time.Duration, int64) ([]string, error)) *MockNamespaceAPI_ListNamespacesByField_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListNamespacesByLabel provides a mock function with given fields: ctx, labelSelector
-func (_m *MockNamespaceAPI) ListNamespacesByLabel(ctx context.Context, labelSelector string) ([]v1.Namespace, error) {
-	ret := _m.Called(ctx, labelSelector)
+// ListNamespacesByLabel provides a mock function with given fields: ctx, labelSelector, timeoutSeconds, limit
+func (_m *MockNamespaceAPI) ListNamespacesByLabel(ctx context.Context, labelSelector string, timeoutSeconds time.Duration, limit int64) ([]string, error) {
+	ret := _m.Called(ctx, labelSelector, timeoutSeconds, limit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListNamespacesByLabel")
 	}
 
-	var r0 []v1.Namespace
+	var r0 []string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) ([]v1.Namespace, error)); ok {
-		return rf(ctx, labelSelector)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, int64) ([]string, error)); ok {
+		return rf(ctx, labelSelector, timeoutSeconds, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) []v1.Namespace); ok {
-		r0 = rf(ctx, labelSelector)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, int64) []string); ok {
+		r0 = rf(ctx, labelSelector, timeoutSeconds, limit)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]v1.Namespace)
+			r0 = ret.Get(0).([]string)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, labelSelector)
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration, int64) error); ok {
+		r1 = rf(ctx, labelSelector, timeoutSeconds, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -178,23 +245,263 @@ type MockNamespaceAPI_ListNamespacesByLabel_Call struct {
 // ListNamespacesByLabel is a helper method to define mock.On call
 //   - ctx context.Context
 //   - labelSelector string
-func (_e *MockNamespaceAPI_Expecter) ListNamespacesByLabel(ctx interface{}, labelSelector interface{}) *MockNamespaceAPI_ListNamespacesByLabel_Call {
-	return &MockNamespaceAPI_ListNamespacesByLabel_Call{Call: _e.mock.On("ListNamespacesByLabel", ctx, labelSelector)}
+//   - timeoutSeconds time.Duration
+//   - limit int64
+func (_e *MockNamespaceAPI_Expecter) ListNamespacesByLabel(ctx interface{}, labelSelector interface{}, timeoutSeconds interface{}, limit interface{}) *MockNamespaceAPI_ListNamespacesByLabel_Call {
+	return &MockNamespaceAPI_ListNamespacesByLabel_Call{Call: _e.mock.On("ListNamespacesByLabel", ctx, labelSelector, timeoutSeconds, limit)}
 }
 
-func (_c *MockNamespaceAPI_ListNamespacesByLabel_Call) Run(run func(ctx context.Context, labelSelector string)) *MockNamespaceAPI_ListNamespacesByLabel_Call {
+func (_c *MockNamespaceAPI_ListNamespacesByLabel_Call) Run(run func(ctx context.Context, labelSelector string, timeoutSeconds time.Duration, limit int64)) *MockNamespaceAPI_ListNamespacesByLabel_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *MockNamespaceAPI_ListNamespacesByLabel_Call) Return(_a0 []string, _a1 error) *MockNamespaceAPI_ListNamespacesByLabel_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockNamespaceAPI_ListNamespacesByLabel_Call) RunAndReturn(run func(context.Context, string, time.Duration, int64) ([]string, error)) *MockNamespaceAPI_ListNamespacesByLabel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListNamespacesByQuery provides a mock function with given fields: ctx, query
+func (_m *MockNamespaceAPI) ListNamespacesByQuery(ctx context.Context, query api.ListQuery) ([]string, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListNamespacesByQuery")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, api.ListQuery) ([]string, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, api.ListQuery) []string); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, api.ListQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockNamespaceAPI_ListNamespacesByQuery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListNamespacesByQuery'
+type MockNamespaceAPI_ListNamespacesByQuery_Call struct {
+	*mock.Call
+}
+
+// ListNamespacesByQuery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query api.ListQuery
+func (_e *MockNamespaceAPI_Expecter) ListNamespacesByQuery(ctx interface{}, query interface{}) *MockNamespaceAPI_ListNamespacesByQuery_Call {
+	return &MockNamespaceAPI_ListNamespacesByQuery_Call{Call: _e.mock.On("ListNamespacesByQuery", ctx, query)}
+}
+
+func (_c *MockNamespaceAPI_ListNamespacesByQuery_Call) Run(run func(ctx context.Context, query api.ListQuery)) *MockNamespaceAPI_ListNamespacesByQuery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(api.ListQuery))
+	})
+	return _c
+}
+
+func (_c *MockNamespaceAPI_ListNamespacesByQuery_Call) Return(_a0 []string, _a1 error) *MockNamespaceAPI_ListNamespacesByQuery_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockNamespaceAPI_ListNamespacesByQuery_Call) RunAndReturn(run func(context.Context, api.ListQuery) ([]string, error)) *MockNamespaceAPI_ListNamespacesByQuery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListNamespacesMetadata provides a mock function with given fields: ctx, labelSelector, timeoutSeconds, limit
+func (_m *MockNamespaceAPI) ListNamespacesMetadata(ctx context.Context, labelSelector string, timeoutSeconds time.Duration, limit int64) ([]v1.PartialObjectMetadata, error) {
+	ret := _m.Called(ctx, labelSelector, timeoutSeconds, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListNamespacesMetadata")
+	}
+
+	var r0 []v1.PartialObjectMetadata
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, int64) ([]v1.PartialObjectMetadata, error)); ok {
+		return rf(ctx, labelSelector, timeoutSeconds, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, int64) []v1.PartialObjectMetadata); ok {
+		r0 = rf(ctx, labelSelector, timeoutSeconds, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]v1.PartialObjectMetadata)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration, int64) error); ok {
+		r1 = rf(ctx, labelSelector, timeoutSeconds, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockNamespaceAPI_ListNamespacesMetadata_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListNamespacesMetadata'
+type MockNamespaceAPI_ListNamespacesMetadata_Call struct {
+	*mock.Call
+}
+
+// ListNamespacesMetadata is a helper method to define mock.On call
+//   - ctx context.Context
+//   - labelSelector string
+//   - timeoutSeconds time.Duration
+//   - limit int64
+func (_e *MockNamespaceAPI_Expecter) ListNamespacesMetadata(ctx interface{}, labelSelector interface{}, timeoutSeconds interface{}, limit interface{}) *MockNamespaceAPI_ListNamespacesMetadata_Call {
+	return &MockNamespaceAPI_ListNamespacesMetadata_Call{Call: _e.mock.On("ListNamespacesMetadata", ctx, labelSelector, timeoutSeconds, limit)}
+}
+
+func (_c *MockNamespaceAPI_ListNamespacesMetadata_Call) Run(run func(ctx context.Context, labelSelector string, timeoutSeconds time.Duration, limit int64)) *MockNamespaceAPI_ListNamespacesMetadata_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *MockNamespaceAPI_ListNamespacesMetadata_Call) Return(_a0 []v1.PartialObjectMetadata, _a1 error) *MockNamespaceAPI_ListNamespacesMetadata_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockNamespaceAPI_ListNamespacesMetadata_Call) RunAndReturn(run func(context.Context, string, time.Duration, int64) ([]v1.PartialObjectMetadata, error)) *MockNamespaceAPI_ListNamespacesMetadata_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListNamespacesSnapshot provides a mock function with given fields: ctx, opts
+func (_m *MockNamespaceAPI) ListNamespacesSnapshot(ctx context.Context, opts api.SnapshotOptions) (*api.Snapshot[string], error) {
+	ret := _m.Called(ctx, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListNamespacesSnapshot")
+	}
+
+	var r0 *api.Snapshot[string]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, api.SnapshotOptions) (*api.Snapshot[string], error)); ok {
+		return rf(ctx, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, api.SnapshotOptions) *api.Snapshot[string]); ok {
+		r0 = rf(ctx, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.Snapshot[string])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, api.SnapshotOptions) error); ok {
+		r1 = rf(ctx, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockNamespaceAPI_ListNamespacesSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListNamespacesSnapshot'
+type MockNamespaceAPI_ListNamespacesSnapshot_Call struct {
+	*mock.Call
+}
+
+// ListNamespacesSnapshot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts api.SnapshotOptions
+func (_e *MockNamespaceAPI_Expecter) ListNamespacesSnapshot(ctx interface{}, opts interface{}) *MockNamespaceAPI_ListNamespacesSnapshot_Call {
+	return &MockNamespaceAPI_ListNamespacesSnapshot_Call{Call: _e.mock.On("ListNamespacesSnapshot", ctx, opts)}
+}
+
+func (_c *MockNamespaceAPI_ListNamespacesSnapshot_Call) Run(run func(ctx context.Context, opts api.SnapshotOptions)) *MockNamespaceAPI_ListNamespacesSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(api.SnapshotOptions))
+	})
+	return _c
+}
+
+func (_c *MockNamespaceAPI_ListNamespacesSnapshot_Call) Return(_a0 *api.Snapshot[string], _a1 error) *MockNamespaceAPI_ListNamespacesSnapshot_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockNamespaceAPI_ListNamespacesSnapshot_Call) RunAndReturn(run func(context.Context, api.SnapshotOptions) (*api.Snapshot[string], error)) *MockNamespaceAPI_ListNamespacesSnapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WatchNamespaces provides a mock function with given fields: ctx, opts
+func (_m *MockNamespaceAPI) WatchNamespaces(ctx context.Context, opts api.WatchOptions) (<-chan api.WatchEvent[*corev1.Namespace], error) {
+	ret := _m.Called(ctx, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WatchNamespaces")
+	}
+
+	var r0 <-chan api.WatchEvent[*corev1.Namespace]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, api.WatchOptions) (<-chan api.WatchEvent[*corev1.Namespace], error)); ok {
+		return rf(ctx, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, api.WatchOptions) <-chan api.WatchEvent[*corev1.Namespace]); ok {
+		r0 = rf(ctx, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan api.WatchEvent[*corev1.Namespace])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, api.WatchOptions) error); ok {
+		r1 = rf(ctx, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockNamespaceAPI_WatchNamespaces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WatchNamespaces'
+type MockNamespaceAPI_WatchNamespaces_Call struct {
+	*mock.Call
+}
+
+// WatchNamespaces is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts api.WatchOptions
+func (_e *MockNamespaceAPI_Expecter) WatchNamespaces(ctx interface{}, opts interface{}) *MockNamespaceAPI_WatchNamespaces_Call {
+	return &MockNamespaceAPI_WatchNamespaces_Call{Call: _e.mock.On("WatchNamespaces", ctx, opts)}
+}
+
+func (_c *MockNamespaceAPI_WatchNamespaces_Call) Run(run func(ctx context.Context, opts api.WatchOptions)) *MockNamespaceAPI_WatchNamespaces_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(api.WatchOptions))
 	})
 	return _c
 }
 
-func (_c *MockNamespaceAPI_ListNamespacesByLabel_Call) Return(_a0 []v1.Namespace, _a1 error) *MockNamespaceAPI_ListNamespacesByLabel_Call {
+func (_c *MockNamespaceAPI_WatchNamespaces_Call) Return(_a0 <-chan api.WatchEvent[*corev1.Namespace], _a1 error) *MockNamespaceAPI_WatchNamespaces_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockNamespaceAPI_ListNamespacesByLabel_Call) RunAndReturn(run func(context.Context, string) ([]v1.Namespace, error)) *MockNamespaceAPI_ListNamespacesByLabel_Call {
+func (_c *MockNamespaceAPI_WatchNamespaces_Call) RunAndReturn(run func(context.Context, api.WatchOptions) (<-chan api.WatchEvent[*corev1.Namespace], error)) *MockNamespaceAPI_WatchNamespaces_Call {
 	_c.Call.Return(run)
 	return _c
 }
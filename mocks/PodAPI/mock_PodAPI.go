@@ -1,11 +1,18 @@
-// Code generated by mockery v2.51.1. DO NOT EDIT.
+// Code generated by mockery v2.53.4. DO NOT EDIT.
 
 package mocksapi
 
 import (
 	context "context"
 
+	api "github.com/kaudit/k8s_client"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
 	v1 "k8s.io/api/core/v1"
 )
 
@@ -82,9 +89,9 @@ func (_c *MockPodAPI_GetPodByName_Call) RunAndReturn(run func(context.Context, s
 	return _c
 }
 
-// ListPodsByField provides a mock function with given fields: ctx, namespace, fieldSelector
-func (_m *MockPodAPI) ListPodsByField(ctx context.Context, namespace string, fieldSelector string) ([]v1.Pod, error) {
-	ret := _m.Called(ctx, namespace, fieldSelector)
+// ListPodsByField provides a mock function with given fields: ctx, namespace, fieldSelector, timeoutSeconds, limit
+func (_m *MockPodAPI) ListPodsByField(ctx context.Context, namespace string, fieldSelector string, timeoutSeconds time.Duration, limit int64) ([]v1.Pod, error) {
+	ret := _m.Called(ctx, namespace, fieldSelector, timeoutSeconds, limit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListPodsByField")
@@ -92,19 +99,19 @@ func (_m *MockPodAPI) ListPodsByField(ctx context.Context, namespace string, fie
 
 	var r0 []v1.Pod
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]v1.Pod, error)); ok {
-		return rf(ctx, namespace, fieldSelector)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration, int64) ([]v1.Pod, error)); ok {
+		return rf(ctx, namespace, fieldSelector, timeoutSeconds, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) []v1.Pod); ok {
-		r0 = rf(ctx, namespace, fieldSelector)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration, int64) []v1.Pod); ok {
+		r0 = rf(ctx, namespace, fieldSelector, timeoutSeconds, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]v1.Pod)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, namespace, fieldSelector)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Duration, int64) error); ok {
+		r1 = rf(ctx, namespace, fieldSelector, timeoutSeconds, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -121,13 +128,15 @@ type MockPodAPI_ListPodsByField_Call struct {
 //   - ctx context.Context
 //   - namespace string
 //   - fieldSelector string
-func (_e *MockPodAPI_Expecter) ListPodsByField(ctx interface{}, namespace interface{}, fieldSelector interface{}) *MockPodAPI_ListPodsByField_Call {
-	return &MockPodAPI_ListPodsByField_Call{Call: _e.mock.On("ListPodsByField", ctx, namespace, fieldSelector)}
+//   - timeoutSeconds time.Duration
+//   - limit int64
+func (_e *MockPodAPI_Expecter) ListPodsByField(ctx interface{}, namespace interface{}, fieldSelector interface{}, timeoutSeconds interface{}, limit interface{}) *MockPodAPI_ListPodsByField_Call {
+	return &MockPodAPI_ListPodsByField_Call{Call: _e.mock.On("ListPodsByField", ctx, namespace, fieldSelector, timeoutSeconds, limit)}
 }
 
-func (_c *MockPodAPI_ListPodsByField_Call) Run(run func(ctx context.Context, namespace string, fieldSelector string)) *MockPodAPI_ListPodsByField_Call {
+func (_c *MockPodAPI_ListPodsByField_Call) Run(run func(ctx context.Context, namespace string, fieldSelector string, timeoutSeconds time.Duration, limit int64)) *MockPodAPI_ListPodsByField_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Duration), args[4].(int64))
 	})
 	return _c
 }
@@ -137,14 +146,14 @@ func (_c *MockPodAPI_ListPodsByField_Call) Return(_a0 []v1.Pod, _a1 error) *Mock
 	return _c
 }
 
-func (_c *MockPodAPI_ListPodsByField_Call) RunAndReturn(run func(context.Context, string, string) ([]v1.Pod, error)) *MockPodAPI_ListPodsByField_Call {
+func (_c *MockPodAPI_ListPodsByField_Call) RunAndReturn(run func(context.Context, string, string, time.Duration, int64) ([]v1.Pod, error)) *MockPodAPI_ListPodsByField_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListPodsByLabel provides a mock function with given fields: ctx, namespace, labelSelector
-func (_m *MockPodAPI) ListPodsByLabel(ctx context.Context, namespace string, labelSelector string) ([]v1.Pod, error) {
-	ret := _m.Called(ctx, namespace, labelSelector)
+// ListPodsByLabel provides a mock function with given fields: ctx, namespace, labelSelector, timeoutSeconds, limit
+func (_m *MockPodAPI) ListPodsByLabel(ctx context.Context, namespace string, labelSelector string, timeoutSeconds time.Duration, limit int64) ([]v1.Pod, error) {
+	ret := _m.Called(ctx, namespace, labelSelector, timeoutSeconds, limit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListPodsByLabel")
@@ -152,19 +161,19 @@ func (_m *MockPodAPI) ListPodsByLabel(ctx context.Context, namespace string, lab
 
 	var r0 []v1.Pod
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]v1.Pod, error)); ok {
-		return rf(ctx, namespace, labelSelector)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration, int64) ([]v1.Pod, error)); ok {
+		return rf(ctx, namespace, labelSelector, timeoutSeconds, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) []v1.Pod); ok {
-		r0 = rf(ctx, namespace, labelSelector)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration, int64) []v1.Pod); ok {
+		r0 = rf(ctx, namespace, labelSelector, timeoutSeconds, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]v1.Pod)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, namespace, labelSelector)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Duration, int64) error); ok {
+		r1 = rf(ctx, namespace, labelSelector, timeoutSeconds, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -181,13 +190,15 @@ type MockPodAPI_ListPodsByLabel_Call struct {
 //   - ctx context.Context
 //   - namespace string
 //   - labelSelector string
-func (_e *MockPodAPI_Expecter) ListPodsByLabel(ctx interface{}, namespace interface{}, labelSelector interface{}) *MockPodAPI_ListPodsByLabel_Call {
-	return &MockPodAPI_ListPodsByLabel_Call{Call: _e.mock.On("ListPodsByLabel", ctx, namespace, labelSelector)}
+//   - timeoutSeconds time.Duration
+//   - limit int64
+func (_e *MockPodAPI_Expecter) ListPodsByLabel(ctx interface{}, namespace interface{}, labelSelector interface{}, timeoutSeconds interface{}, limit interface{}) *MockPodAPI_ListPodsByLabel_Call {
+	return &MockPodAPI_ListPodsByLabel_Call{Call: _e.mock.On("ListPodsByLabel", ctx, namespace, labelSelector, timeoutSeconds, limit)}
 }
 
-func (_c *MockPodAPI_ListPodsByLabel_Call) Run(run func(ctx context.Context, namespace string, labelSelector string)) *MockPodAPI_ListPodsByLabel_Call {
+func (_c *MockPodAPI_ListPodsByLabel_Call) Run(run func(ctx context.Context, namespace string, labelSelector string, timeoutSeconds time.Duration, limit int64)) *MockPodAPI_ListPodsByLabel_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Duration), args[4].(int64))
 	})
 	return _c
 }
@@ -197,7 +208,249 @@ func (_c *MockPodAPI_ListPodsByLabel_Call) Return(_a0 []v1.Pod, _a1 error) *Mock
 	return _c
 }
 
-func (_c *MockPodAPI_ListPodsByLabel_Call) RunAndReturn(run func(context.Context, string, string) ([]v1.Pod, error)) *MockPodAPI_ListPodsByLabel_Call {
+func (_c *MockPodAPI_ListPodsByLabel_Call) RunAndReturn(run func(context.Context, string, string, time.Duration, int64) ([]v1.Pod, error)) *MockPodAPI_ListPodsByLabel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPodsByQuery provides a mock function with given fields: ctx, namespace, query
+func (_m *MockPodAPI) ListPodsByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]v1.Pod, error) {
+	ret := _m.Called(ctx, namespace, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPodsByQuery")
+	}
+
+	var r0 []v1.Pod
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, api.ListQuery) ([]v1.Pod, error)); ok {
+		return rf(ctx, namespace, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, api.ListQuery) []v1.Pod); ok {
+		r0 = rf(ctx, namespace, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]v1.Pod)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, api.ListQuery) error); ok {
+		r1 = rf(ctx, namespace, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPodAPI_ListPodsByQuery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPodsByQuery'
+type MockPodAPI_ListPodsByQuery_Call struct {
+	*mock.Call
+}
+
+// ListPodsByQuery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+//   - query api.ListQuery
+func (_e *MockPodAPI_Expecter) ListPodsByQuery(ctx interface{}, namespace interface{}, query interface{}) *MockPodAPI_ListPodsByQuery_Call {
+	return &MockPodAPI_ListPodsByQuery_Call{Call: _e.mock.On("ListPodsByQuery", ctx, namespace, query)}
+}
+
+func (_c *MockPodAPI_ListPodsByQuery_Call) Run(run func(ctx context.Context, namespace string, query api.ListQuery)) *MockPodAPI_ListPodsByQuery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(api.ListQuery))
+	})
+	return _c
+}
+
+func (_c *MockPodAPI_ListPodsByQuery_Call) Return(_a0 []v1.Pod, _a1 error) *MockPodAPI_ListPodsByQuery_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPodAPI_ListPodsByQuery_Call) RunAndReturn(run func(context.Context, string, api.ListQuery) ([]v1.Pod, error)) *MockPodAPI_ListPodsByQuery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPodsMetadata provides a mock function with given fields: ctx, namespace, labelSelector, timeoutSeconds, limit
+func (_m *MockPodAPI) ListPodsMetadata(ctx context.Context, namespace string, labelSelector string, timeoutSeconds time.Duration, limit int64) ([]metav1.PartialObjectMetadata, error) {
+	ret := _m.Called(ctx, namespace, labelSelector, timeoutSeconds, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPodsMetadata")
+	}
+
+	var r0 []metav1.PartialObjectMetadata
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration, int64) ([]metav1.PartialObjectMetadata, error)); ok {
+		return rf(ctx, namespace, labelSelector, timeoutSeconds, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration, int64) []metav1.PartialObjectMetadata); ok {
+		r0 = rf(ctx, namespace, labelSelector, timeoutSeconds, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]metav1.PartialObjectMetadata)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Duration, int64) error); ok {
+		r1 = rf(ctx, namespace, labelSelector, timeoutSeconds, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPodAPI_ListPodsMetadata_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPodsMetadata'
+type MockPodAPI_ListPodsMetadata_Call struct {
+	*mock.Call
+}
+
+// ListPodsMetadata is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+//   - labelSelector string
+//   - timeoutSeconds time.Duration
+//   - limit int64
+func (_e *MockPodAPI_Expecter) ListPodsMetadata(ctx interface{}, namespace interface{}, labelSelector interface{}, timeoutSeconds interface{}, limit interface{}) *MockPodAPI_ListPodsMetadata_Call {
+	return &MockPodAPI_ListPodsMetadata_Call{Call: _e.mock.On("ListPodsMetadata", ctx, namespace, labelSelector, timeoutSeconds, limit)}
+}
+
+func (_c *MockPodAPI_ListPodsMetadata_Call) Run(run func(ctx context.Context, namespace string, labelSelector string, timeoutSeconds time.Duration, limit int64)) *MockPodAPI_ListPodsMetadata_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Duration), args[4].(int64))
+	})
+	return _c
+}
+
+func (_c *MockPodAPI_ListPodsMetadata_Call) Return(_a0 []metav1.PartialObjectMetadata, _a1 error) *MockPodAPI_ListPodsMetadata_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPodAPI_ListPodsMetadata_Call) RunAndReturn(run func(context.Context, string, string, time.Duration, int64) ([]metav1.PartialObjectMetadata, error)) *MockPodAPI_ListPodsMetadata_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPodsSnapshot provides a mock function with given fields: ctx, namespace, opts
+func (_m *MockPodAPI) ListPodsSnapshot(ctx context.Context, namespace string, opts api.SnapshotOptions) (*api.Snapshot[v1.Pod], error) {
+	ret := _m.Called(ctx, namespace, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPodsSnapshot")
+	}
+
+	var r0 *api.Snapshot[v1.Pod]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, api.SnapshotOptions) (*api.Snapshot[v1.Pod], error)); ok {
+		return rf(ctx, namespace, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, api.SnapshotOptions) *api.Snapshot[v1.Pod]); ok {
+		r0 = rf(ctx, namespace, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.Snapshot[v1.Pod])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, api.SnapshotOptions) error); ok {
+		r1 = rf(ctx, namespace, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPodAPI_ListPodsSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPodsSnapshot'
+type MockPodAPI_ListPodsSnapshot_Call struct {
+	*mock.Call
+}
+
+// ListPodsSnapshot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+//   - opts api.SnapshotOptions
+func (_e *MockPodAPI_Expecter) ListPodsSnapshot(ctx interface{}, namespace interface{}, opts interface{}) *MockPodAPI_ListPodsSnapshot_Call {
+	return &MockPodAPI_ListPodsSnapshot_Call{Call: _e.mock.On("ListPodsSnapshot", ctx, namespace, opts)}
+}
+
+func (_c *MockPodAPI_ListPodsSnapshot_Call) Run(run func(ctx context.Context, namespace string, opts api.SnapshotOptions)) *MockPodAPI_ListPodsSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(api.SnapshotOptions))
+	})
+	return _c
+}
+
+func (_c *MockPodAPI_ListPodsSnapshot_Call) Return(_a0 *api.Snapshot[v1.Pod], _a1 error) *MockPodAPI_ListPodsSnapshot_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPodAPI_ListPodsSnapshot_Call) RunAndReturn(run func(context.Context, string, api.SnapshotOptions) (*api.Snapshot[v1.Pod], error)) *MockPodAPI_ListPodsSnapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WatchPods provides a mock function with given fields: ctx, namespace, opts
+func (_m *MockPodAPI) WatchPods(ctx context.Context, namespace string, opts api.WatchOptions) (<-chan api.WatchEvent[*v1.Pod], error) {
+	ret := _m.Called(ctx, namespace, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WatchPods")
+	}
+
+	var r0 <-chan api.WatchEvent[*v1.Pod]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, api.WatchOptions) (<-chan api.WatchEvent[*v1.Pod], error)); ok {
+		return rf(ctx, namespace, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, api.WatchOptions) <-chan api.WatchEvent[*v1.Pod]); ok {
+		r0 = rf(ctx, namespace, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan api.WatchEvent[*v1.Pod])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, api.WatchOptions) error); ok {
+		r1 = rf(ctx, namespace, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPodAPI_WatchPods_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WatchPods'
+type MockPodAPI_WatchPods_Call struct {
+	*mock.Call
+}
+
+// WatchPods is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+//   - opts api.WatchOptions
+func (_e *MockPodAPI_Expecter) WatchPods(ctx interface{}, namespace interface{}, opts interface{}) *MockPodAPI_WatchPods_Call {
+	return &MockPodAPI_WatchPods_Call{Call: _e.mock.On("WatchPods", ctx, namespace, opts)}
+}
+
+func (_c *MockPodAPI_WatchPods_Call) Run(run func(ctx context.Context, namespace string, opts api.WatchOptions)) *MockPodAPI_WatchPods_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(api.WatchOptions))
+	})
+	return _c
+}
+
+func (_c *MockPodAPI_WatchPods_Call) Return(_a0 <-chan api.WatchEvent[*v1.Pod], _a1 error) *MockPodAPI_WatchPods_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPodAPI_WatchPods_Call) RunAndReturn(run func(context.Context, string, api.WatchOptions) (<-chan api.WatchEvent[*v1.Pod], error)) *MockPodAPI_WatchPods_Call {
 	_c.Call.Return(run)
 	return _c
 }
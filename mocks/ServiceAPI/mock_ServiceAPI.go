@@ -1,11 +1,18 @@
-// Code generated by mockery v2.51.1. DO NOT EDIT.
+// Code generated by mockery v2.53.4. DO NOT EDIT.
 
 package mocksapi
 
 import (
 	context "context"
 
+	api "github.com/kaudit/k8s_client"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
 	v1 "k8s.io/api/core/v1"
 )
 
@@ -82,9 +89,9 @@ func (_c *MockServiceAPI_GetServiceByName_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
-// ListServicesByField provides a mock function with given fields: ctx, namespace, fieldSelector
-func (_m *MockServiceAPI) ListServicesByField(ctx context.Context, namespace string, fieldSelector string) ([]v1.Service, error) {
-	ret := _m.Called(ctx, namespace, fieldSelector)
+// ListServicesByField provides a mock function with given fields: ctx, namespace, fieldSelector, timeoutSeconds, limit
+func (_m *MockServiceAPI) ListServicesByField(ctx context.Context, namespace string, fieldSelector string, timeoutSeconds time.Duration, limit int64) ([]v1.Service, error) {
+	ret := _m.Called(ctx, namespace, fieldSelector, timeoutSeconds, limit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListServicesByField")
@@ -92,19 +99,19 @@ func (_m *MockServiceAPI) ListServicesByField(ctx context.Context, namespace str
 
 	var r0 []v1.Service
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]v1.Service, error)); ok {
-		return rf(ctx, namespace, fieldSelector)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration, int64) ([]v1.Service, error)); ok {
+		return rf(ctx, namespace, fieldSelector, timeoutSeconds, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) []v1.Service); ok {
-		r0 = rf(ctx, namespace, fieldSelector)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration, int64) []v1.Service); ok {
+		r0 = rf(ctx, namespace, fieldSelector, timeoutSeconds, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]v1.Service)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, namespace, fieldSelector)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Duration, int64) error); ok {
+		r1 = rf(ctx, namespace, fieldSelector, timeoutSeconds, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -121,13 +128,15 @@ type MockServiceAPI_ListServicesByField_Call struct {
 //   - ctx context.Context
 //   - namespace string
 //   - fieldSelector string
-func (_e *MockServiceAPI_Expecter) ListServicesByField(ctx interface{}, namespace interface{}, fieldSelector interface{}) *MockServiceAPI_ListServicesByField_Call {
-	return &MockServiceAPI_ListServicesByField_Call{Call: _e.mock.On("ListServicesByField", ctx, namespace, fieldSelector)}
+//   - timeoutSeconds time.Duration
+//   - limit int64
+func (_e *MockServiceAPI_Expecter) ListServicesByField(ctx interface{}, namespace interface{}, fieldSelector interface{}, timeoutSeconds interface{}, limit interface{}) *MockServiceAPI_ListServicesByField_Call {
+	return &MockServiceAPI_ListServicesByField_Call{Call: _e.mock.On("ListServicesByField", ctx, namespace, fieldSelector, timeoutSeconds, limit)}
 }
 
-func (_c *MockServiceAPI_ListServicesByField_Call) Run(run func(ctx context.Context, namespace string, fieldSelector string)) *MockServiceAPI_ListServicesByField_Call {
+func (_c *MockServiceAPI_ListServicesByField_Call) Run(run func(ctx context.Context, namespace string, fieldSelector string, timeoutSeconds time.Duration, limit int64)) *MockServiceAPI_ListServicesByField_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Duration), args[4].(int64))
 	})
 	return _c
 }
@@ -137,14 +146,14 @@ func (_c *MockServiceAPI_ListServicesByField_Call) Return(_a0 []v1.Service, _a1
 	return _c
 }
 
-func (_c *MockServiceAPI_ListServicesByField_Call) RunAndReturn(run func(context.Context, string, string) ([]v1.Service, error)) *MockServiceAPI_ListServicesByField_Call {
+func (_c *MockServiceAPI_ListServicesByField_Call) RunAndReturn(run func(context.Context, string, string, time.Duration, int64) ([]v1.Service, error)) *MockServiceAPI_ListServicesByField_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListServicesByLabel provides a mock function with given fields: ctx, namespace, labelSelector
-func (_m *MockServiceAPI) ListServicesByLabel(ctx context.Context, namespace string, labelSelector string) ([]v1.Service, error) {
-	ret := _m.Called(ctx, namespace, labelSelector)
+// ListServicesByLabel provides a mock function with given fields: ctx, namespace, labelSelector, timeoutSeconds, limit
+func (_m *MockServiceAPI) ListServicesByLabel(ctx context.Context, namespace string, labelSelector string, timeoutSeconds time.Duration, limit int64) ([]v1.Service, error) {
+	ret := _m.Called(ctx, namespace, labelSelector, timeoutSeconds, limit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListServicesByLabel")
@@ -152,19 +161,19 @@ func (_m *MockServiceAPI) ListServicesByLabel(ctx context.Context, namespace str
 
 	var r0 []v1.Service
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]v1.Service, error)); ok {
-		return rf(ctx, namespace, labelSelector)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration, int64) ([]v1.Service, error)); ok {
+		return rf(ctx, namespace, labelSelector, timeoutSeconds, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) []v1.Service); ok {
-		r0 = rf(ctx, namespace, labelSelector)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration, int64) []v1.Service); ok {
+		r0 = rf(ctx, namespace, labelSelector, timeoutSeconds, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]v1.Service)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, namespace, labelSelector)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Duration, int64) error); ok {
+		r1 = rf(ctx, namespace, labelSelector, timeoutSeconds, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -181,13 +190,15 @@ type MockServiceAPI_ListServicesByLabel_Call struct {
 //   - ctx context.Context
 //   - namespace string
 //   - labelSelector string
-func (_e *MockServiceAPI_Expecter) ListServicesByLabel(ctx interface{}, namespace interface{}, labelSelector interface{}) *MockServiceAPI_ListServicesByLabel_Call {
-	return &MockServiceAPI_ListServicesByLabel_Call{Call: _e.mock.On("ListServicesByLabel", ctx, namespace, labelSelector)}
+//   - timeoutSeconds time.Duration
+//   - limit int64
+func (_e *MockServiceAPI_Expecter) ListServicesByLabel(ctx interface{}, namespace interface{}, labelSelector interface{}, timeoutSeconds interface{}, limit interface{}) *MockServiceAPI_ListServicesByLabel_Call {
+	return &MockServiceAPI_ListServicesByLabel_Call{Call: _e.mock.On("ListServicesByLabel", ctx, namespace, labelSelector, timeoutSeconds, limit)}
 }
 
-func (_c *MockServiceAPI_ListServicesByLabel_Call) Run(run func(ctx context.Context, namespace string, labelSelector string)) *MockServiceAPI_ListServicesByLabel_Call {
+func (_c *MockServiceAPI_ListServicesByLabel_Call) Run(run func(ctx context.Context, namespace string, labelSelector string, timeoutSeconds time.Duration, limit int64)) *MockServiceAPI_ListServicesByLabel_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Duration), args[4].(int64))
 	})
 	return _c
 }
@@ -197,7 +208,249 @@ func (_c *MockServiceAPI_ListServicesByLabel_Call) Return(_a0 []v1.Service, _a1
 	return _c
 }
 
-func (_c *MockServiceAPI_ListServicesByLabel_Call) RunAndReturn(run func(context.Context, string, string) ([]v1.Service, error)) *MockServiceAPI_ListServicesByLabel_Call {
+func (_c *MockServiceAPI_ListServicesByLabel_Call) RunAndReturn(run func(context.Context, string, string, time.Duration, int64) ([]v1.Service, error)) *MockServiceAPI_ListServicesByLabel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListServicesByQuery provides a mock function with given fields: ctx, namespace, query
+func (_m *MockServiceAPI) ListServicesByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]v1.Service, error) {
+	ret := _m.Called(ctx, namespace, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListServicesByQuery")
+	}
+
+	var r0 []v1.Service
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, api.ListQuery) ([]v1.Service, error)); ok {
+		return rf(ctx, namespace, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, api.ListQuery) []v1.Service); ok {
+		r0 = rf(ctx, namespace, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]v1.Service)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, api.ListQuery) error); ok {
+		r1 = rf(ctx, namespace, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockServiceAPI_ListServicesByQuery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListServicesByQuery'
+type MockServiceAPI_ListServicesByQuery_Call struct {
+	*mock.Call
+}
+
+// ListServicesByQuery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+//   - query api.ListQuery
+func (_e *MockServiceAPI_Expecter) ListServicesByQuery(ctx interface{}, namespace interface{}, query interface{}) *MockServiceAPI_ListServicesByQuery_Call {
+	return &MockServiceAPI_ListServicesByQuery_Call{Call: _e.mock.On("ListServicesByQuery", ctx, namespace, query)}
+}
+
+func (_c *MockServiceAPI_ListServicesByQuery_Call) Run(run func(ctx context.Context, namespace string, query api.ListQuery)) *MockServiceAPI_ListServicesByQuery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(api.ListQuery))
+	})
+	return _c
+}
+
+func (_c *MockServiceAPI_ListServicesByQuery_Call) Return(_a0 []v1.Service, _a1 error) *MockServiceAPI_ListServicesByQuery_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockServiceAPI_ListServicesByQuery_Call) RunAndReturn(run func(context.Context, string, api.ListQuery) ([]v1.Service, error)) *MockServiceAPI_ListServicesByQuery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListServicesMetadata provides a mock function with given fields: ctx, namespace, labelSelector, timeoutSeconds, limit
+func (_m *MockServiceAPI) ListServicesMetadata(ctx context.Context, namespace string, labelSelector string, timeoutSeconds time.Duration, limit int64) ([]metav1.PartialObjectMetadata, error) {
+	ret := _m.Called(ctx, namespace, labelSelector, timeoutSeconds, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListServicesMetadata")
+	}
+
+	var r0 []metav1.PartialObjectMetadata
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration, int64) ([]metav1.PartialObjectMetadata, error)); ok {
+		return rf(ctx, namespace, labelSelector, timeoutSeconds, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration, int64) []metav1.PartialObjectMetadata); ok {
+		r0 = rf(ctx, namespace, labelSelector, timeoutSeconds, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]metav1.PartialObjectMetadata)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Duration, int64) error); ok {
+		r1 = rf(ctx, namespace, labelSelector, timeoutSeconds, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockServiceAPI_ListServicesMetadata_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListServicesMetadata'
+type MockServiceAPI_ListServicesMetadata_Call struct {
+	*mock.Call
+}
+
+// ListServicesMetadata is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+//   - labelSelector string
+//   - timeoutSeconds time.Duration
+//   - limit int64
+func (_e *MockServiceAPI_Expecter) ListServicesMetadata(ctx interface{}, namespace interface{}, labelSelector interface{}, timeoutSeconds interface{}, limit interface{}) *MockServiceAPI_ListServicesMetadata_Call {
+	return &MockServiceAPI_ListServicesMetadata_Call{Call: _e.mock.On("ListServicesMetadata", ctx, namespace, labelSelector, timeoutSeconds, limit)}
+}
+
+func (_c *MockServiceAPI_ListServicesMetadata_Call) Run(run func(ctx context.Context, namespace string, labelSelector string, timeoutSeconds time.Duration, limit int64)) *MockServiceAPI_ListServicesMetadata_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Duration), args[4].(int64))
+	})
+	return _c
+}
+
+func (_c *MockServiceAPI_ListServicesMetadata_Call) Return(_a0 []metav1.PartialObjectMetadata, _a1 error) *MockServiceAPI_ListServicesMetadata_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockServiceAPI_ListServicesMetadata_Call) RunAndReturn(run func(context.Context, string, string, time.Duration, int64) ([]metav1.PartialObjectMetadata, error)) *MockServiceAPI_ListServicesMetadata_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListServicesSnapshot provides a mock function with given fields: ctx, namespace, opts
+func (_m *MockServiceAPI) ListServicesSnapshot(ctx context.Context, namespace string, opts api.SnapshotOptions) (*api.Snapshot[v1.Service], error) {
+	ret := _m.Called(ctx, namespace, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListServicesSnapshot")
+	}
+
+	var r0 *api.Snapshot[v1.Service]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, api.SnapshotOptions) (*api.Snapshot[v1.Service], error)); ok {
+		return rf(ctx, namespace, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, api.SnapshotOptions) *api.Snapshot[v1.Service]); ok {
+		r0 = rf(ctx, namespace, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.Snapshot[v1.Service])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, api.SnapshotOptions) error); ok {
+		r1 = rf(ctx, namespace, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockServiceAPI_ListServicesSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListServicesSnapshot'
+type MockServiceAPI_ListServicesSnapshot_Call struct {
+	*mock.Call
+}
+
+// ListServicesSnapshot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+//   - opts api.SnapshotOptions
+func (_e *MockServiceAPI_Expecter) ListServicesSnapshot(ctx interface{}, namespace interface{}, opts interface{}) *MockServiceAPI_ListServicesSnapshot_Call {
+	return &MockServiceAPI_ListServicesSnapshot_Call{Call: _e.mock.On("ListServicesSnapshot", ctx, namespace, opts)}
+}
+
+func (_c *MockServiceAPI_ListServicesSnapshot_Call) Run(run func(ctx context.Context, namespace string, opts api.SnapshotOptions)) *MockServiceAPI_ListServicesSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(api.SnapshotOptions))
+	})
+	return _c
+}
+
+func (_c *MockServiceAPI_ListServicesSnapshot_Call) Return(_a0 *api.Snapshot[v1.Service], _a1 error) *MockServiceAPI_ListServicesSnapshot_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockServiceAPI_ListServicesSnapshot_Call) RunAndReturn(run func(context.Context, string, api.SnapshotOptions) (*api.Snapshot[v1.Service], error)) *MockServiceAPI_ListServicesSnapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WatchServices provides a mock function with given fields: ctx, namespace, opts
+func (_m *MockServiceAPI) WatchServices(ctx context.Context, namespace string, opts api.WatchOptions) (<-chan api.WatchEvent[*v1.Service], error) {
+	ret := _m.Called(ctx, namespace, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WatchServices")
+	}
+
+	var r0 <-chan api.WatchEvent[*v1.Service]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, api.WatchOptions) (<-chan api.WatchEvent[*v1.Service], error)); ok {
+		return rf(ctx, namespace, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, api.WatchOptions) <-chan api.WatchEvent[*v1.Service]); ok {
+		r0 = rf(ctx, namespace, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan api.WatchEvent[*v1.Service])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, api.WatchOptions) error); ok {
+		r1 = rf(ctx, namespace, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockServiceAPI_WatchServices_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WatchServices'
+type MockServiceAPI_WatchServices_Call struct {
+	*mock.Call
+}
+
+// WatchServices is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+//   - opts api.WatchOptions
+func (_e *MockServiceAPI_Expecter) WatchServices(ctx interface{}, namespace interface{}, opts interface{}) *MockServiceAPI_WatchServices_Call {
+	return &MockServiceAPI_WatchServices_Call{Call: _e.mock.On("WatchServices", ctx, namespace, opts)}
+}
+
+func (_c *MockServiceAPI_WatchServices_Call) Run(run func(ctx context.Context, namespace string, opts api.WatchOptions)) *MockServiceAPI_WatchServices_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(api.WatchOptions))
+	})
+	return _c
+}
+
+func (_c *MockServiceAPI_WatchServices_Call) Return(_a0 <-chan api.WatchEvent[*v1.Service], _a1 error) *MockServiceAPI_WatchServices_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockServiceAPI_WatchServices_Call) RunAndReturn(run func(context.Context, string, api.WatchOptions) (<-chan api.WatchEvent[*v1.Service], error)) *MockServiceAPI_WatchServices_Call {
 	_c.Call.Return(run)
 	return _c
 }
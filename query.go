@@ -0,0 +1,36 @@
+package api
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ListQuery describes a paginated list combining any of the supported list parameters.
+//
+// Label and field selectors are optional and applied together, so a query for
+// "app=web" and "status.phase=Running" returns only running web pods.
+// ResourceVersion and ResourceVersionMatch control the consistency of the first page;
+// ResourceVersionMatch requires ResourceVersion to be set.
+type ListQuery struct {
+	LabelSelector        string                      `validate:"omitempty,k8s_label_selector"`
+	FieldSelector        string                      `validate:"omitempty,k8s_field_selector"`
+	ResourceVersion      string                      `validate:"required_with=ResourceVersionMatch"`
+	ResourceVersionMatch metav1.ResourceVersionMatch `validate:"omitempty,oneof=NotOlderThan Exact"`
+	TimeoutSeconds       time.Duration               `validate:"required,min=1s"`
+	Limit                int64                       `validate:"required,gt=0"`
+}
+
+// ListOptions converts the query into the metav1.ListOptions of its first page.
+func (q ListQuery) ListOptions() metav1.ListOptions {
+	seconds := int64(q.TimeoutSeconds.Seconds())
+
+	return metav1.ListOptions{
+		LabelSelector:        q.LabelSelector,
+		FieldSelector:        q.FieldSelector,
+		ResourceVersion:      q.ResourceVersion,
+		ResourceVersionMatch: q.ResourceVersionMatch,
+		Limit:                q.Limit,
+		TimeoutSeconds:       &seconds,
+	}
+}
@@ -0,0 +1,30 @@
+package api
+
+import "time"
+
+// RetryPolicy configures how transient API failures are retried.
+//
+// MaxAttempts is the total number of attempts including the first one. The delay before
+// attempt n+1 is InitialBackoff doubled n-1 times, capped at MaxBackoff, and extended by a
+// random fraction of up to Jitter of itself. A Retry-After delay suggested by the API server
+// is used instead when it is longer.
+//
+// Only throttling (429), unavailable (503), gateway and server timeouts, and dropped
+// connections are retried; validation failures and other 4xx responses never are.
+type RetryPolicy struct {
+	MaxAttempts    int           `validate:"gte=1"`
+	InitialBackoff time.Duration `validate:"required,gt=0"`
+	MaxBackoff     time.Duration `validate:"required,gtefield=InitialBackoff"`
+	Jitter         float64       `validate:"gte=0,lte=1"`
+}
+
+// DefaultRetryPolicy returns a policy of 5 attempts with backoff growing from 200ms
+// up to 10s and 20% jitter.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		Jitter:         0.2,
+	}
+}
@@ -0,0 +1,103 @@
+package selector
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// ErrUnsupportedField is returned by Build when a field cannot be selected on the resource.
+var ErrUnsupportedField = errors.New("field selector not supported")
+
+// Resource identifies the kind of object a field selector is built for.
+type Resource string
+
+// Resources with field selector support.
+const (
+	Pods        Resource = "pods"
+	Services    Resource = "services"
+	Deployments Resource = "deployments"
+	Namespaces  Resource = "namespaces"
+)
+
+// SupportedFields returns the field selector keys accepted for the resource.
+// The list is the intersection of the fields the API server indexes for the resource
+// and the fields accepted by the k8s_field_selector validation of the resource APIs.
+// It returns nil for unknown resources.
+func SupportedFields(resource Resource) []string {
+	switch resource {
+	case Pods:
+		return []string{"metadata.name", "metadata.namespace", "spec.nodeName",
+			"status.phase", "status.hostIP", "status.podIP"}
+	case Services:
+		return []string{"metadata.name", "metadata.namespace", "spec.type"}
+	case Deployments:
+		return []string{"metadata.name", "metadata.namespace"}
+	case Namespaces:
+		return []string{"metadata.name", "status.phase"}
+	default:
+		return nil
+	}
+}
+
+// FieldBuilder builds a field selector for a single resource from a list of terms
+// combined with AND. The first invalid term is recorded and returned by Build.
+type FieldBuilder struct {
+	resource Resource
+	terms    []fields.Selector
+	err      error
+}
+
+// Fields returns an empty FieldBuilder for the resource.
+func Fields(resource Resource) *FieldBuilder {
+	return &FieldBuilder{resource: resource}
+}
+
+// Equals requires the field to have the given value.
+func (b *FieldBuilder) Equals(field, value string) *FieldBuilder {
+	return b.add(field, value, fields.OneTermEqualSelector)
+}
+
+// NotEquals requires the field to have a value other than the given one.
+func (b *FieldBuilder) NotEquals(field, value string) *FieldBuilder {
+	return b.add(field, value, fields.OneTermNotEqualSelector)
+}
+
+// Build returns the field selector string.
+//
+// Returns ErrEmptySelector if no term was added, or the error of the first invalid term.
+func (b *FieldBuilder) Build() (string, error) {
+	if b.err != nil {
+		return "", b.err
+	}
+	if len(b.terms) == 0 {
+		return "", ErrEmptySelector
+	}
+
+	return fields.AndSelectors(b.terms...).String(), nil
+}
+
+// add validates and records a single term.
+// Values needing escapes are rejected because the resource API validation splits
+// selectors on commas without honoring escape sequences.
+func (b *FieldBuilder) add(field, value string, term func(k, v string) fields.Selector) *FieldBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	if !slices.Contains(SupportedFields(b.resource), field) {
+		b.err = fmt.Errorf("%w: %q on %s", ErrUnsupportedField, field, b.resource)
+		return b
+	}
+	if strings.ContainsAny(value, `\,=`) {
+		b.err = fmt.Errorf("invalid value %q for field %q: must not contain '\\', ',' or '='", value, field)
+		return b
+	}
+
+	b.terms = append(b.terms, term(field, value))
+
+	return b
+}
@@ -0,0 +1,93 @@
+package selector
+
+import (
+	"testing"
+
+	"github.com/kaudit/val"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldBuilder(t *testing.T) {
+	tests := []struct {
+		name          string
+		builder       *FieldBuilder
+		want          string
+		wantErr       error
+		errorContains string
+	}{
+		{
+			name:    "Pod fields combined",
+			builder: Fields(Pods).Equals("status.phase", "Running").NotEquals("spec.nodeName", "node-1"),
+			want:    "status.phase=Running,spec.nodeName!=node-1",
+		},
+		{
+			name:    "Service type",
+			builder: Fields(Services).Equals("spec.type", "NodePort"),
+			want:    "spec.type=NodePort",
+		},
+		{
+			name:    "Deployment name",
+			builder: Fields(Deployments).Equals("metadata.name", "web"),
+			want:    "metadata.name=web",
+		},
+		{
+			name:    "Namespace phase",
+			builder: Fields(Namespaces).Equals("status.phase", "Active"),
+			want:    "status.phase=Active",
+		},
+		{
+			name:    "Empty builder",
+			builder: Fields(Pods),
+			wantErr: ErrEmptySelector,
+		},
+		{
+			name:          "Field unsupported by resource",
+			builder:       Fields(Deployments).Equals("status.phase", "Running"),
+			wantErr:       ErrUnsupportedField,
+			errorContains: "\"status.phase\" on deployments",
+		},
+		{
+			name:    "Unknown resource",
+			builder: Fields(Resource("secrets")).Equals("metadata.name", "token"),
+			wantErr: ErrUnsupportedField,
+		},
+		{
+			name:          "Value needing escape",
+			builder:       Fields(Pods).Equals("metadata.name", "a,b"),
+			errorContains: "invalid value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.builder.Build()
+
+			if tt.wantErr != nil || tt.errorContains != "" {
+				require.Error(t, err)
+				if tt.wantErr != nil {
+					assert.ErrorIs(t, err, tt.wantErr)
+				}
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Empty(t, got)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, val.ValidateWithTag(got, "required,k8s_field_selector"))
+		})
+	}
+}
+
+func TestSupportedFields(t *testing.T) {
+	for _, resource := range []Resource{Pods, Services, Deployments, Namespaces} {
+		t.Run(string(resource), func(t *testing.T) {
+			for _, field := range SupportedFields(resource) {
+				assert.NoError(t, val.ValidateWithTag(field+"=x", "k8s_field_selector"))
+			}
+		})
+	}
+
+	assert.Nil(t, SupportedFields(Resource("secrets")))
+}
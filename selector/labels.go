@@ -0,0 +1,89 @@
+// Package selector builds label and field selector strings accepted by the resource APIs.
+// Builders validate every requirement as it is added, so malformed keys, values and
+// unsupported fields are reported before any request reaches the API server.
+package selector
+
+import (
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+// ErrEmptySelector is returned by Build when no requirement was added.
+var ErrEmptySelector = errors.New("selector has no requirements")
+
+// LabelBuilder builds a label selector from a list of requirements combined with AND.
+// The first invalid requirement is recorded and returned by Build.
+type LabelBuilder struct {
+	requirements labels.Requirements
+	err          error
+}
+
+// Labels returns an empty LabelBuilder.
+func Labels() *LabelBuilder {
+	return &LabelBuilder{}
+}
+
+// Equals requires the label key to have the given value.
+func (b *LabelBuilder) Equals(key, value string) *LabelBuilder {
+	return b.add(key, selection.Equals, value)
+}
+
+// NotEquals requires the label key to be absent or to have a value other than the given one.
+func (b *LabelBuilder) NotEquals(key, value string) *LabelBuilder {
+	return b.add(key, selection.NotEquals, value)
+}
+
+// In requires the label key to have one of the given values.
+func (b *LabelBuilder) In(key string, values ...string) *LabelBuilder {
+	return b.add(key, selection.In, values...)
+}
+
+// NotIn requires the label key to be absent or to have none of the given values.
+func (b *LabelBuilder) NotIn(key string, values ...string) *LabelBuilder {
+	return b.add(key, selection.NotIn, values...)
+}
+
+// Exists requires the label key to be present with any value.
+func (b *LabelBuilder) Exists(key string) *LabelBuilder {
+	return b.add(key, selection.Exists)
+}
+
+// DoesNotExist requires the label key to be absent.
+func (b *LabelBuilder) DoesNotExist(key string) *LabelBuilder {
+	return b.add(key, selection.DoesNotExist)
+}
+
+// Build returns the label selector string.
+//
+// Returns ErrEmptySelector if no requirement was added, or the error of the first
+// invalid requirement.
+func (b *LabelBuilder) Build() (string, error) {
+	if b.err != nil {
+		return "", b.err
+	}
+	if len(b.requirements) == 0 {
+		return "", ErrEmptySelector
+	}
+
+	return labels.NewSelector().Add(b.requirements...).String(), nil
+}
+
+// add validates and records a single requirement.
+func (b *LabelBuilder) add(key string, op selection.Operator, values ...string) *LabelBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	requirement, err := labels.NewRequirement(key, op, values)
+	if err != nil {
+		b.err = fmt.Errorf("invalid label requirement on %q: %w", key, err)
+		return b
+	}
+
+	b.requirements = append(b.requirements, *requirement)
+
+	return b
+}
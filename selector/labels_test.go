@@ -0,0 +1,81 @@
+package selector
+
+import (
+	"testing"
+
+	"github.com/kaudit/val"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabelBuilder(t *testing.T) {
+	tests := []struct {
+		name          string
+		builder       *LabelBuilder
+		want          string
+		wantErr       error
+		errorContains string
+	}{
+		{
+			name:    "Equals",
+			builder: Labels().Equals("app", "web"),
+			want:    "app=web",
+		},
+		{
+			name: "All operators combined",
+			builder: Labels().
+				Equals("app", "web").
+				NotEquals("tier", "cache").
+				In("env", "prod", "staging").
+				NotIn("zone", "a").
+				Exists("owner").
+				DoesNotExist("legacy"),
+			want: "app=web,env in (prod,staging),!legacy,owner,tier!=cache,zone notin (a)",
+		},
+		{
+			name:    "Empty builder",
+			builder: Labels(),
+			wantErr: ErrEmptySelector,
+		},
+		{
+			name:          "Invalid key",
+			builder:       Labels().Equals("bad key", "web"),
+			errorContains: "invalid label requirement on \"bad key\"",
+		},
+		{
+			name:          "Invalid value",
+			builder:       Labels().Equals("app", "web/1"),
+			errorContains: "invalid label requirement on \"app\"",
+		},
+		{
+			name:          "In without values",
+			builder:       Labels().In("env"),
+			errorContains: "invalid label requirement on \"env\"",
+		},
+		{
+			name:          "First error is kept",
+			builder:       Labels().Equals("bad key", "web").Equals("app", "web"),
+			errorContains: "bad key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.builder.Build()
+
+			if tt.wantErr != nil || tt.errorContains != "" {
+				require.Error(t, err)
+				if tt.wantErr != nil {
+					assert.ErrorIs(t, err, tt.wantErr)
+				}
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Empty(t, got)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, val.ValidateWithTag(got, "required,k8s_label_selector"))
+		})
+	}
+}
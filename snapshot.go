@@ -0,0 +1,66 @@
+package api
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultSnapshotRestarts is the number of times a snapshot list is restarted after its
+// continue token expired when SnapshotOptions.MaxRestarts is left at zero.
+const DefaultSnapshotRestarts = 3
+
+// ExpiredContinuePolicy selects how a snapshot list reacts when the API server rejects
+// its continue token with 410 Gone because the snapshot was compacted away.
+type ExpiredContinuePolicy int
+
+const (
+	// ExpiredContinueRestart discards the pages collected so far and restarts the list
+	// from the first page at a fresh resourceVersion.
+	ExpiredContinueRestart ExpiredContinuePolicy = iota
+	// ExpiredContinuePartial stops listing and returns the items collected so far
+	// together with a *ContinueExpiredError.
+	ExpiredContinuePartial
+)
+
+// SnapshotOptions configures a paginated list that is read at a single resourceVersion.
+// Both selectors are optional and may be combined; when empty, all objects are listed.
+type SnapshotOptions struct {
+	LabelSelector  string                `validate:"omitempty,k8s_label_selector"`
+	FieldSelector  string                `validate:"omitempty,k8s_field_selector"`
+	TimeoutSeconds time.Duration         `validate:"required,min=1s"`
+	Limit          int64                 `validate:"required,gt=0"`
+	OnExpired      ExpiredContinuePolicy `validate:"gte=0,lte=1"`
+	MaxRestarts    int                   `validate:"gte=0"`
+}
+
+// Snapshot holds the result of a paginated list read at a single resourceVersion.
+//
+// ResourceVersion is the version reported by the first page of the last attempt; all items
+// belong to that version. Restarts counts how many times the list was started over after
+// its continue token expired. Complete is false when only a partial result was collected.
+type Snapshot[T any] struct {
+	Items           []T
+	ResourceVersion string
+	Restarts        int
+	Complete        bool
+}
+
+// ContinueExpiredError is returned by snapshot lists when the continue token expired and the
+// list could not be completed. Collected is the number of items gathered before the failure.
+type ContinueExpiredError struct {
+	ResourceVersion string
+	Collected       int
+	Restarts        int
+	Err             error
+}
+
+// Error implements the error interface.
+func (e *ContinueExpiredError) Error() string {
+	return fmt.Sprintf("continue token for resourceVersion %q expired after %d items and %d restarts: %v",
+		e.ResourceVersion, e.Collected, e.Restarts, e.Err)
+}
+
+// Unwrap returns the underlying API server error.
+func (e *ContinueExpiredError) Unwrap() error {
+	return e.Err
+}
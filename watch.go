@@ -0,0 +1,30 @@
+package api
+
+// EventType identifies the kind of change delivered by a watch.
+type EventType string
+
+const (
+	// EventAdded is delivered when an object is created or, after the watch had to be
+	// restarted from the current state, when an existing object is replayed.
+	EventAdded EventType = "ADDED"
+	// EventModified is delivered when an existing object is updated.
+	EventModified EventType = "MODIFIED"
+	// EventDeleted is delivered when an object is removed.
+	EventDeleted EventType = "DELETED"
+)
+
+// WatchOptions configures a watch. Both selectors are optional and may be combined.
+//
+// ResourceVersion selects where the watch starts. When empty, the current state is
+// delivered as EventAdded events before subsequent changes.
+type WatchOptions struct {
+	LabelSelector   string `validate:"omitempty,k8s_label_selector"`
+	FieldSelector   string `validate:"omitempty,k8s_field_selector"`
+	ResourceVersion string
+}
+
+// WatchEvent is a typed change notification for a single object.
+type WatchEvent[T any] struct {
+	Type   EventType
+	Object T
+}